@@ -0,0 +1,229 @@
+package metaphone3
+
+import "unicode"
+
+// Likelihoods of the secondary pronunciation at a fork, relative to the primary.
+// Most forks use altEven, rules where the secondary is a rarer pronunciation
+// use altUnlikely.
+const (
+	altEven     = 0.5
+	altUnlikely = 0.25
+)
+
+// segment is a single addition to the outputs, prim is what was added to the
+// primary and second is what was added to the secondary.  When they differ the
+// pronunciation forks at this point and weight is the likelihood of the secondary
+// relative to the primary.
+type segment struct {
+	prim, second string
+	weight       float64
+}
+
+func (e *Encoder) addSegment(prim, second string, weight float64) {
+	e.segments = append(e.segments, segment{prim, second, weight})
+}
+
+func runeStr(r rune) string {
+	if r == unicode.ReplacementChar {
+		return ""
+	}
+	return string(r)
+}
+
+// branch is one path through the forks in the segments
+type branch struct {
+	buf []rune
+	// isPrim and isSec are set when this branch is (or has merged with)
+	// the path that always takes the primary or the secondary
+	isPrim, isSec bool
+	// flips is the fewest number of forks where this branch didn't take the primary
+	flips int
+	// weight is the likelihood of this branch relative to the primary
+	weight float64
+}
+
+// Alternate is a key along with its likelihood relative to the primary key.
+// The primary always has a Weight of 1.
+type Alternate struct {
+	Key    string
+	Weight float64
+}
+
+// EncodeAll returns every distinct key for the input, following each fork in the
+// pronunciation independently rather than only the all-primary and all-secondary paths.
+// The first key is always the primary from Encode and the second is the secondary (if
+// there is one).  The remaining keys are ordered from most to least likely.
+// At most MaxAlternates keys are returned.
+func (e *Encoder) EncodeAll(in string) []string {
+	branches := e.encodeBranches(in)
+	if branches == nil {
+		return nil
+	}
+
+	keys := make([]string, len(branches))
+	for i, b := range branches {
+		keys[i] = string(b.buf)
+	}
+	return keys
+}
+
+// EncodeWeighted returns the same keys as EncodeAll, each with a weight for how likely
+// that pronunciation is compared to the primary.  Each fork in the pronunciation has a
+// likelihood for its alternate (most are 0.5) and the weight of a key is the product of the
+// likelihoods of the alternates it took.  Note that the secondary can be less likely
+// than other alternates since it takes the alternate at every fork.
+func (e *Encoder) EncodeWeighted(in string) []Alternate {
+	branches := e.encodeBranches(in)
+	if branches == nil {
+		return nil
+	}
+
+	alts := make([]Alternate, len(branches))
+	for i, b := range branches {
+		alts[i] = Alternate{Key: string(b.buf), Weight: b.weight}
+	}
+	return alts
+}
+
+// MatchWeight returns how strongly two sets of weighted keys match, the highest
+// product of the weights of any key they share.  Primary to primary matches have
+// a weight of 1 and matches that rely on alternates are down-weighted.  Zero means
+// there's no shared key.
+func MatchWeight(a, b []Alternate) float64 {
+	best := 0.0
+	for _, x := range a {
+		for _, y := range b {
+			if x.Key == y.Key && x.Weight*y.Weight > best {
+				best = x.Weight * y.Weight
+			}
+		}
+	}
+	return best
+}
+
+// encodeBranches runs the encoder with segment tracking and rebuilds the best branches
+func (e *Encoder) encodeBranches(in string) []*branch {
+	e.trackSegments = true
+	defer func() { e.trackSegments = false }()
+
+	if prim, _ := e.encode(in); prim == "" && len(e.segments) == 0 {
+		return nil
+	}
+
+	limit := e.MaxAlternates
+	if limit <= 0 {
+		limit = DefaultMaxAlternates
+	}
+	// keep at least the primary and secondary
+	if limit < 2 {
+		limit = 2
+	}
+
+	branches := []*branch{{isPrim: true, isSec: true, weight: 1}}
+	for _, seg := range e.segments {
+		if seg.prim == seg.second {
+			for _, b := range branches {
+				b.buf = appendSegment(b.buf, seg.prim, e.maxLen)
+			}
+			continue
+		}
+
+		next := make([]*branch, 0, len(branches)*2)
+		for _, b := range branches {
+			alt := &branch{
+				buf:    appendSegment(append([]rune(nil), b.buf...), seg.second, e.maxLen),
+				isSec:  b.isSec,
+				flips:  b.flips + 1,
+				weight: b.weight * seg.weight,
+			}
+			b.buf = appendSegment(b.buf, seg.prim, e.maxLen)
+			b.isSec = false
+			next = append(next, b, alt)
+		}
+		branches = pruneBranches(next, limit)
+	}
+
+	if ex := e.supplement; ex != nil {
+		// the supplement takes over as the secondary when the rules didn't have one
+		replaced := branches[0].isSec
+		for i, k := range []string{ex.Primary, ex.Secondary} {
+			if k == "" {
+				continue
+			}
+			b := &branch{buf: []rune(k), flips: 1, weight: altEven}
+			if i == 0 && replaced {
+				b.isSec = true
+				branches[0].isSec = false
+			}
+			branches = append(branches, b)
+		}
+	}
+
+	for _, b := range branches {
+		if len(b.buf) > e.maxLen {
+			b.buf = b.buf[:e.maxLen]
+		}
+	}
+	return pruneBranches(branches, limit)
+}
+
+// appendSegment adds val to buf the same way the encoder adds to its outputs,
+// once buf is past the max length nothing more is needed
+func appendSegment(buf []rune, val string, maxLen int) []rune {
+	if len(buf) >= maxLen {
+		return buf
+	}
+	// don't dupe added A's
+	if val == "A" && len(buf) > 0 && buf[len(buf)-1] == 'A' {
+		return buf
+	}
+	return append(buf, []rune(val)...)
+}
+
+// pruneBranches merges branches with the same output, since they'll behave the same
+// from here on, and then keeps the best ranked branches up to the limit
+func pruneBranches(branches []*branch, limit int) []*branch {
+	out := branches[:0]
+nextBranch:
+	for _, b := range branches {
+		for _, o := range out {
+			if areEqual(o.buf, b.buf) {
+				o.isPrim = o.isPrim || b.isPrim
+				o.isSec = o.isSec || b.isSec
+				if b.flips < o.flips {
+					o.flips = b.flips
+				}
+				if b.weight > o.weight {
+					o.weight = b.weight
+				}
+				continue nextBranch
+			}
+		}
+		out = append(out, b)
+	}
+
+	// stable insertion sort by rank, there are only ever a handful of branches
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && branchLess(out[j], out[j-1]); j-- {
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func branchLess(a, b *branch) bool {
+	if a.isPrim != b.isPrim {
+		return a.isPrim
+	}
+	if a.isSec != b.isSec {
+		return a.isSec
+	}
+	if a.weight != b.weight {
+		return a.weight > b.weight
+	}
+	return a.flips < b.flips
+}
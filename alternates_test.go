@@ -0,0 +1,102 @@
+package metaphone3
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeAll_Basic(t *testing.T) {
+	vals := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"A", []string{"A"}},
+		{"Ache", []string{"AK", "AX"}},
+		{"Smith", []string{"SM0", "XMT", "SMT", "XM0"}},
+		{"Jorge Chavarria", []string{"JRJXFR", "HRKKFR", "JRJKFR", "JRKXFR", "HRJXFR", "JRKKFR", "HRKXFR", "HRJKFR"}},
+	}
+	e := &Encoder{}
+
+	for _, v := range vals {
+		if got := e.EncodeAll(v.in); !reflect.DeepEqual(v.want, got) {
+			t.Errorf("Invalid output on '%v', wanted %v, got %v", v.in, v.want, got)
+		}
+	}
+}
+
+func TestEncodeAll_Limit(t *testing.T) {
+	e := &Encoder{MaxAlternates: 3}
+	if want, got := []string{"JRJXFR", "HRKKFR", "JRJKFR"}, e.EncodeAll("Jorge Chavarria"); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestEncodeAll_MatchesEncode(t *testing.T) {
+	f, err := os.Open("testdata/firstnames-us.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	encs := []*Encoder{
+		{},
+		{EncodeVowels: true},
+		{EncodeExact: true},
+		{EncodeVowels: true, EncodeExact: true, MaxLength: 4},
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		in := scanner.Text()
+		for _, e := range encs {
+			prim, sec := e.Encode(in)
+			all := e.EncodeAll(in)
+
+			want := []string{prim}
+			if sec != "" {
+				want = append(want, sec)
+			}
+			if len(all) < len(want) || !reflect.DeepEqual(want, all[:len(want)]) {
+				t.Fatalf("EncodeAll of '%v' wanted to start with %v, got %v", in, want, all)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncodeWeighted(t *testing.T) {
+	e := &Encoder{}
+	want := []Alternate{{"JN", 1}, {"AN", altUnlikely}}
+	if got := e.EncodeWeighted("John"); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	want = []Alternate{{"SM0", 1}, {"XMT", altEven * altEven}, {"SMT", altEven}, {"XM0", altEven}}
+	if got := e.EncodeWeighted("Smith"); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestMatchWeight(t *testing.T) {
+	e := &Encoder{}
+	vals := []struct {
+		a, b string
+		want float64
+	}{
+		{"Smith", "Smyth", 1},
+		{"Smith", "Schmidt", altEven * altEven},
+		{"John", "Ian", altUnlikely},
+		{"John", "Smith", 0},
+	}
+
+	for _, v := range vals {
+		if got := MatchWeight(e.EncodeWeighted(v.a), e.EncodeWeighted(v.b)); got != v.want {
+			t.Errorf("MatchWeight of '%v' and '%v' wanted %v, got %v", v.a, v.b, v.want, got)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package blevefilter adapts the Metaphone 3 token filter to Bleve.
+//
+// Importing the package registers the filter as "metaphone3", so it can be used in
+// custom analyzers of an index mapping:
+//
+//	err := m.AddCustomTokenFilter("phonetic", map[string]interface{}{
+//		"type": blevefilter.Name,
+//		"mode": "inject",
+//	})
+//
+// The config can set "mode" ("inject" or "replace", default inject), "vowels", "exact",
+// "max_length" (at most 64) and "primary_only".  Put the filter after to_lower so the literal tokens
+// and the keys are in different cases.
+package blevefilter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/dlclark/metaphone3"
+	m3analysis "github.com/dlclark/metaphone3/analysis"
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+// Name is the name the filter is registered with
+const Name = "metaphone3"
+
+// TokenFilter is a Bleve analysis.TokenFilter that adds Metaphone 3 keys.  Keys are
+// added as keyword tokens so stemmers leave them alone, and keyword tokens aren't encoded.
+type TokenFilter struct {
+	f *m3analysis.Filter
+}
+
+// New returns a filter that encodes with the options of opts (nil for the defaults).
+func New(opts *metaphone3.Encoder, mode m3analysis.Mode) *TokenFilter {
+	return &TokenFilter{f: m3analysis.NewFilter(opts, mode)}
+}
+
+// NewFromFilter returns a Bleve filter for a configured filter.
+func NewFromFilter(f *m3analysis.Filter) *TokenFilter {
+	return &TokenFilter{f: f}
+}
+
+// Filter adds or swaps in the keys of the tokens in the stream.
+func (t *TokenFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	out := make(analysis.TokenStream, 0, len(input)*2)
+	for _, tok := range input {
+		if tok.KeyWord {
+			out = append(out, tok)
+			continue
+		}
+
+		keep, keys := t.f.Expand(string(tok.Term))
+		if keep {
+			out = append(out, tok)
+		}
+		for _, k := range keys {
+			out = append(out, &analysis.Token{
+				Term:     []byte(k),
+				Position: tok.Position,
+				Start:    tok.Start,
+				End:      tok.End,
+				Type:     tok.Type,
+				KeyWord:  true,
+			})
+		}
+	}
+	return out
+}
+
+// Constructor builds the filter from an index mapping config.
+func Constructor(config map[string]interface{}, cache *registry.Cache) (analysis.TokenFilter, error) {
+	mode := m3analysis.Inject
+	if v, ok := config["mode"]; ok {
+		switch v {
+		case "inject":
+		case "replace":
+			mode = m3analysis.Replace
+		default:
+			return nil, fmt.Errorf("metaphone3: unknown mode %v, must be inject or replace", v)
+		}
+	}
+
+	var opts metaphone3.Encoder
+	var primaryOnly bool
+	for name, dst := range map[string]*bool{"vowels": &opts.EncodeVowels, "exact": &opts.EncodeExact, "primary_only": &primaryOnly} {
+		if v, ok := config[name]; ok {
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("metaphone3: %v must be a bool", name)
+			}
+			*dst = b
+		}
+	}
+	if v, ok := config["max_length"]; ok {
+		n, ok := toInt(v)
+		if !ok {
+			return nil, fmt.Errorf("metaphone3: max_length must be a whole number")
+		}
+		if n < 0 || n > pools.MaxLength {
+			return nil, fmt.Errorf("metaphone3: max_length: %w", pools.ErrMaxLength)
+		}
+		opts.MaxLength = n
+	}
+
+	return NewFromFilter(m3analysis.NewFilter(&opts, mode).PrimaryOnly(primaryOnly)), nil
+}
+
+// toInt converts a whole number of any numeric type to an int, configs decoded from
+// JSON have float64 numbers but configs built in Go can use any type
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), int64(int(n)) == n
+	case uint:
+		return int(n), int(n) >= 0
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), int(n) >= 0
+	case uint64:
+		return int(n), n <= uint64(^uint(0)>>1)
+	case float32:
+		return toInt(float64(n))
+	case float64:
+		return int(n), n == float64(int(n))
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return toInt(i)
+	}
+	return 0, false
+}
+
+func init() {
+	if err := registry.RegisterTokenFilter(Name, Constructor); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,134 @@
+package blevefilter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	m3analysis "github.com/dlclark/metaphone3/analysis"
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+func terms(ts analysis.TokenStream) []string {
+	var out []string
+	for _, t := range ts {
+		out = append(out, string(t.Term))
+	}
+	return out
+}
+
+func TestFilter(t *testing.T) {
+	input := analysis.TokenStream{
+		{Term: []byte("smith"), Position: 1, Start: 0, End: 5},
+		{Term: []byte("running"), Position: 2, Start: 6, End: 13, KeyWord: true},
+	}
+
+	out := New(nil, m3analysis.Inject).Filter(input)
+	if want, got := []string{"smith", "SM0", "XMT", "running"}, terms(out); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+	for _, tok := range out[1:3] {
+		if tok.Position != 1 || tok.Start != 0 || tok.End != 5 || !tok.KeyWord {
+			t.Fatalf("wanted a keyword at the position of smith, got %v", tok)
+		}
+	}
+
+	out = New(nil, m3analysis.Replace).Filter(input)
+	if want, got := []string{"SM0", "XMT", "running"}, terms(out); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestConstructor(t *testing.T) {
+	f, err := Constructor(map[string]interface{}{"mode": "replace", "vowels": true, "max_length": 3.0, "primary_only": true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := f.Filter(analysis.TokenStream{{Term: []byte("smith"), Position: 1}})
+	if want, got := []string{"SMA"}, terms(out); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	// configs built in Go can use any numeric type
+	for _, n := range []interface{}{3, int64(3), uint8(3), float32(3), json.Number("3")} {
+		f, err := Constructor(map[string]interface{}{"mode": "replace", "vowels": true, "max_length": n, "primary_only": true}, nil)
+		if err != nil {
+			t.Fatalf("max_length %T: %v", n, err)
+		}
+		out := f.Filter(analysis.TokenStream{{Term: []byte("smith"), Position: 1}})
+		if want, got := []string{"SMA"}, terms(out); !reflect.DeepEqual(want, got) {
+			t.Fatalf("max_length %T: wanted %v, got %v", n, want, got)
+		}
+	}
+
+	for _, bad := range []map[string]interface{}{
+		{"mode": "nope"},
+		{"vowels": "yes"},
+		{"max_length": -1.0},
+		{"max_length": 2.5},
+		{"max_length": -1},
+		{"max_length": "3"},
+		{"max_length": json.Number("3.5")},
+	} {
+		if _, err := Constructor(bad, nil); err == nil {
+			t.Errorf("%v: wanted an error", bad)
+		}
+	}
+
+	for _, n := range []interface{}{-1, 65.0, json.Number("65")} {
+		if _, err := Constructor(map[string]interface{}{"max_length": n}, nil); !errors.Is(err, pools.ErrMaxLength) {
+			t.Errorf("max_length %v: wanted ErrMaxLength, got %v", n, err)
+		}
+	}
+	if _, err := Constructor(map[string]interface{}{"max_length": 64}, nil); err != nil {
+		t.Errorf("max_length 64: %v", err)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	m := bleve.NewIndexMapping()
+	if err := m.AddCustomTokenFilter("phonetic", map[string]interface{}{"type": Name}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddCustomAnalyzer("names", map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name, "phonetic"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	m.DefaultAnalyzer = "names"
+
+	idx, err := bleve.NewMemOnly(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+
+	for id, name := range map[string]string{"1": "John Smith", "2": "Jane Schmidt", "3": "Mary Jones"} {
+		if err := idx.Index(id, map[string]string{"name": name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := bleve.NewMatchQuery("Smyth")
+	q.SetField("name")
+	res, err := idx.Search(bleve.NewSearchRequest(q))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, h := range res.Hits {
+		ids = append(ids, h.ID)
+	}
+	// Smyth sounds like Smith, and its secondary XMT is Schmidt's primary
+	if want := []string{"1", "2"}; !reflect.DeepEqual(want, ids) {
+		t.Fatalf("wanted hits %v, got %v", want, ids)
+	}
+}
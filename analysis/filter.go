@@ -0,0 +1,117 @@
+// Package analysis adds Metaphone 3 keys to the token streams of full-text search engines,
+// so that names can be searched by how they sound as well as how they're spelled.
+//
+// The keys are emitted as synonyms at the same position as the token they came from,
+// either next to the token (Inject) or in place of it (Replace).  The blevefilter
+// subpackage adapts the filter to Bleve.
+package analysis
+
+import "github.com/dlclark/metaphone3"
+
+// Token is a term at a position in a token stream.
+type Token struct {
+	Term string
+	// Position of the token in the stream, phonetic tokens have the same position
+	// as the token they came from
+	Position int
+	// Start and End are the byte offsets of the token in the text
+	Start, End int
+	// Phonetic is set on the tokens added by the filter
+	Phonetic bool
+}
+
+// Mode is whether the filter keeps the original tokens.
+type Mode int
+
+const (
+	// Inject keeps each token and adds its keys after it
+	Inject Mode = iota
+	// Replace swaps each token for its keys, tokens without keys (e.g. numbers) are kept
+	Replace
+)
+
+// Filter emits the Metaphone 3 keys of tokens.  It's safe to use across goroutines.
+type Filter struct {
+	mode Mode
+	// primaryOnly skips the secondary keys
+	primaryOnly bool
+	pool        *metaphone3.Pool
+}
+
+// NewFilter returns a filter that encodes with the options of opts (nil for the defaults)
+// and emits both the primary and secondary keys.
+func NewFilter(opts *metaphone3.Encoder, mode Mode) *Filter {
+	return &Filter{mode: mode, pool: metaphone3.NewPool(opts)}
+}
+
+// PrimaryOnly sets whether the filter emits only the primary keys and returns the filter.
+func (f *Filter) PrimaryOnly(b bool) *Filter {
+	f.primaryOnly = b
+	return f
+}
+
+// Mode returns the mode of the filter.
+func (f *Filter) Mode() Mode {
+	return f.mode
+}
+
+// Keys returns the keys emitted for a term using an encoder from the filter's pool,
+// none if the term has no key.
+func (f *Filter) Keys(term string) []string {
+	e := f.pool.Get()
+	defer f.pool.Put(e)
+	return f.keys(e, term)
+}
+
+func (f *Filter) keys(e *metaphone3.Encoder, term string) []string {
+	prim, sec := e.Encode(term)
+	if prim == "" {
+		return nil
+	}
+	if sec == "" || f.primaryOnly {
+		return []string{prim}
+	}
+	return []string{prim, sec}
+}
+
+// Expand returns whether a token for the term stays in the stream and the keys to add
+// after it, which is what Filter does for each token.  It's for adapting the filter to
+// the token types of other engines.
+func (f *Filter) Expand(term string) (keep bool, keys []string) {
+	e := f.pool.Get()
+	defer f.pool.Put(e)
+	return f.expand(e, term)
+}
+
+func (f *Filter) expand(e *metaphone3.Encoder, term string) (bool, []string) {
+	keys := f.keys(e, term)
+	if f.mode == Replace && len(keys) > 0 {
+		return false, keys
+	}
+	// a key that's the same as the term would be a duplicate token
+	out := keys[:0]
+	for _, k := range keys {
+		if k != term {
+			out = append(out, k)
+		}
+	}
+	return true, out
+}
+
+// Filter returns the token stream with the keys added or swapped in.
+func (f *Filter) Filter(in []Token) []Token {
+	e := f.pool.Get()
+	defer f.pool.Put(e)
+
+	out := make([]Token, 0, len(in)*2)
+	for _, t := range in {
+		keep, keys := f.expand(e, t.Term)
+		if keep {
+			out = append(out, t)
+		}
+		for _, k := range keys {
+			out = append(out, Token{Term: k, Position: t.Position, Start: t.Start, End: t.End, Phonetic: true})
+		}
+	}
+	return out
+}
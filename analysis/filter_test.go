@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dlclark/metaphone3"
+)
+
+func testTokens() []Token {
+	return []Token{
+		{Term: "john", Position: 1, Start: 0, End: 4},
+		{Term: "smith", Position: 2, Start: 5, End: 10},
+		{Term: "42", Position: 3, Start: 11, End: 13},
+	}
+}
+
+func TestFilter_Inject(t *testing.T) {
+	want := []Token{
+		{Term: "john", Position: 1, Start: 0, End: 4},
+		{Term: "JN", Position: 1, Start: 0, End: 4, Phonetic: true},
+		{Term: "AN", Position: 1, Start: 0, End: 4, Phonetic: true},
+		{Term: "smith", Position: 2, Start: 5, End: 10},
+		{Term: "SM0", Position: 2, Start: 5, End: 10, Phonetic: true},
+		{Term: "XMT", Position: 2, Start: 5, End: 10, Phonetic: true},
+		{Term: "42", Position: 3, Start: 11, End: 13},
+	}
+	if got := NewFilter(nil, Inject).Filter(testTokens()); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestFilter_Replace(t *testing.T) {
+	want := []Token{
+		{Term: "JN", Position: 1, Start: 0, End: 4, Phonetic: true},
+		{Term: "SM0", Position: 2, Start: 5, End: 10, Phonetic: true},
+		{Term: "42", Position: 3, Start: 11, End: 13},
+	}
+	f := NewFilter(nil, Replace).PrimaryOnly(true)
+	if got := f.Filter(testTokens()); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestFilter_Options(t *testing.T) {
+	f := NewFilter(&metaphone3.Encoder{EncodeVowels: true}, Replace)
+	if want, got := []string{"SMA0", "XMAT"}, f.Keys("smith"); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+	if got := f.Keys("42"); got != nil {
+		t.Fatalf("wanted no keys, got %v", got)
+	}
+}
+
+func TestFilter_Expand(t *testing.T) {
+	vals := []struct {
+		mode Mode
+		term string
+		keep bool
+		keys []string
+	}{
+		{Inject, "smith", true, []string{"SM0", "XMT"}},
+		// a key that's the same as the term isn't added again
+		{Inject, "SM", true, []string{"XM"}},
+		{Replace, "smith", false, []string{"SM0", "XMT"}},
+		{Replace, "42", true, nil},
+	}
+	for _, v := range vals {
+		keep, keys := NewFilter(nil, v.mode).Expand(v.term)
+		if keep != v.keep || len(keys) != len(v.keys) || (len(keys) > 0 && !reflect.DeepEqual(v.keys, keys)) {
+			t.Errorf("%v %v: wanted %v %v, got %v %v", v.mode, v.term, v.keep, v.keys, keep, keys)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Package blocking generates candidate record pairs for record linkage using
+// Metaphone 3 keys as blocking keys.  Rather than comparing every record in one
+// set with every record in another, records are grouped into blocks that share
+// a phonetic key and only records within the same block are paired up.
+package blocking
+
+import (
+	"sort"
+	"unicode"
+
+	"github.com/dlclark/metaphone3"
+)
+
+// Record is a person record from one of the data sets being linked.
+type Record struct {
+	ID        string
+	GivenName string
+	Surname   string
+}
+
+// KeyFunc returns the blocking key values for a record.  A record can be
+// placed in more than one block, and blank values are ignored.
+type KeyFunc func(e *metaphone3.Encoder, r Record) []string
+
+// Key is a named blocking key definition.
+type Key struct {
+	Name string
+	Func KeyFunc
+}
+
+// SurnamePrimary blocks on the primary metaphone of the surname.
+var SurnamePrimary = Key{
+	Name: "surname-primary",
+	Func: func(e *metaphone3.Encoder, r Record) []string {
+		prim, _ := e.Encode(r.Surname)
+		return []string{prim}
+	},
+}
+
+// SurnameSecondary blocks on the secondary metaphone of the surname.  Surnames
+// without a secondary metaphone are not placed in a block by this key.
+var SurnameSecondary = Key{
+	Name: "surname-secondary",
+	Func: func(e *metaphone3.Encoder, r Record) []string {
+		_, sec := e.Encode(r.Surname)
+		return []string{sec}
+	},
+}
+
+// SurnameEither blocks on both the primary and secondary metaphones of the
+// surname, so a primary on one side can meet a secondary on the other.
+var SurnameEither = Key{
+	Name: "surname-either",
+	Func: func(e *metaphone3.Encoder, r Record) []string {
+		prim, sec := e.Encode(r.Surname)
+		return []string{prim, sec}
+	},
+}
+
+// GivenInitialSurname blocks on the first letter of the given name, upper cased,
+// followed by the primary metaphone of the surname.
+var GivenInitialSurname = Key{
+	Name: "given-initial-surname",
+	Func: func(e *metaphone3.Encoder, r Record) []string {
+		prim, _ := e.Encode(r.Surname)
+		if prim == "" {
+			return nil
+		}
+		for _, c := range r.GivenName {
+			if unicode.IsLetter(c) {
+				return []string{string(unicode.ToUpper(c)) + ":" + prim}
+			}
+		}
+		return nil
+	},
+}
+
+// GivenSurname blocks on the primary metaphones of both the given name and
+// the surname.
+var GivenSurname = Key{
+	Name: "given-surname",
+	Func: func(e *metaphone3.Encoder, r Record) []string {
+		given, _ := e.Encode(r.GivenName)
+		sur, _ := e.Encode(r.Surname)
+		if given == "" || sur == "" {
+			return nil
+		}
+		return []string{given + ":" + sur}
+	},
+}
+
+// Pair is a candidate pair of records that share at least one block.
+type Pair struct {
+	Left, Right Record
+	// Keys holds the names of the blocking keys the two records share, in the
+	// order the keys were configured
+	Keys []string
+}
+
+// Blocker builds blocks from the configured keys and generates candidate pairs.
+// The Encoder options are applied to every key.  A Blocker is not safe to use
+// across goroutines.
+type Blocker struct {
+	// Keys are the blocking keys to use, a pair is generated when two records
+	// share a block for any of the keys
+	Keys []Key
+
+	// Encoder is used to build the phonetic keys, if nil a default Encoder is used
+	Encoder *metaphone3.Encoder
+}
+
+// New returns a Blocker that uses the given keys and a default Encoder.
+func New(keys ...Key) *Blocker {
+	return &Blocker{Keys: keys}
+}
+
+func (b *Blocker) encoder() *metaphone3.Encoder {
+	if b.Encoder == nil {
+		b.Encoder = &metaphone3.Encoder{}
+	}
+	return b.Encoder
+}
+
+// index maps a block value to the positions of the records in that block,
+// one map per configured key
+type index []map[string][]int
+
+// blockValues returns the block values of each record, one list for each key
+func (b *Blocker) blockValues(recs []Record) [][][]string {
+	e := b.encoder()
+	out := make([][][]string, len(recs))
+	for i, r := range recs {
+		out[i] = b.recordValues(e, r)
+	}
+	return out
+}
+
+// recordValues returns the block values of a record for each key
+func (b *Blocker) recordValues(e *metaphone3.Encoder, r Record) [][]string {
+	vals := make([][]string, len(b.Keys))
+	for k, key := range b.Keys {
+		vals[k] = dedupe(key.Func(e, r))
+	}
+	return vals
+}
+
+func (b *Blocker) buildIndex(vals [][][]string) index {
+	idx := make(index, len(b.Keys))
+	for k := range b.Keys {
+		m := make(map[string][]int)
+		for i := range vals {
+			for _, v := range vals[i][k] {
+				m[v] = append(m[v], i)
+			}
+		}
+		idx[k] = m
+	}
+	return idx
+}
+
+// Pairs calls fn for every candidate pair of a left and a right record that share
+// a block.  Each pair is generated once even when the records share several blocks.
+// Only the blocks of the right records are held in memory, the pairs are streamed
+// as the left records are visited in order.  If fn returns false generation stops early.
+func (b *Blocker) Pairs(left, right []Record, fn func(Pair) bool) {
+	e := b.encoder()
+	b.pairs(left, func(i int) [][]string { return b.recordValues(e, left[i]) },
+		right, b.buildIndex(b.blockValues(right)), fn)
+}
+
+// pairs is Pairs with the block values of each left record from leftVals and the
+// index of the right records already built
+func (b *Blocker) pairs(left []Record, leftVals func(i int) [][]string, right []Record, idx index, fn func(Pair) bool) {
+	// which keys matched each right record for the current left record
+	matched := make(map[int][]string)
+	var order []int
+
+	for li, l := range left {
+		vals := leftVals(li)
+		for k, key := range b.Keys {
+			for _, v := range vals[k] {
+				for _, ri := range idx[k][v] {
+					names, ok := matched[ri]
+					if !ok {
+						order = append(order, ri)
+					}
+					if len(names) == 0 || names[len(names)-1] != key.Name {
+						matched[ri] = append(names, key.Name)
+					}
+				}
+			}
+		}
+
+		sort.Ints(order)
+		for _, ri := range order {
+			if !fn(Pair{Left: l, Right: right[ri], Keys: matched[ri]}) {
+				return
+			}
+		}
+
+		for _, ri := range order {
+			delete(matched, ri)
+		}
+		order = order[:0]
+	}
+}
+
+// KeyStats holds block size statistics for a single blocking key.
+type KeyStats struct {
+	Name string
+	// Blocks is the number of blocks that contain records from both sets
+	Blocks int
+	// MaxBlock is the largest number of pairs generated by a single block
+	MaxBlock int
+	// Pairs is the number of pairs generated by this key alone
+	Pairs int
+	// Unblocked is the number of records from both sets that had no value for this key
+	Unblocked int
+}
+
+// Stats describes the blocks built from two record sets.
+type Stats struct {
+	// Keys has the stats for each configured key, in order
+	Keys []KeyStats
+	// CrossProduct is the number of pairs without any blocking
+	CrossProduct int
+	// Pairs is the number of distinct candidate pairs across all keys
+	Pairs int
+}
+
+// ReductionRatio is the fraction of the cross product that blocking avoids comparing.
+func (s Stats) ReductionRatio() float64 {
+	if s.CrossProduct == 0 {
+		return 0
+	}
+	return 1 - float64(s.Pairs)/float64(s.CrossProduct)
+}
+
+// Stats computes block size statistics for the given record sets.  Each record is
+// encoded once.
+func (b *Blocker) Stats(left, right []Record) Stats {
+	st := Stats{
+		Keys:         make([]KeyStats, len(b.Keys)),
+		CrossProduct: len(left) * len(right),
+	}
+
+	leftVals := b.blockValues(left)
+	rightVals := b.blockValues(right)
+	leftIdx := b.buildIndex(leftVals)
+	rightIdx := b.buildIndex(rightVals)
+
+	for k, key := range b.Keys {
+		ks := KeyStats{Name: key.Name}
+		for v, ls := range leftIdx[k] {
+			rs, ok := rightIdx[k][v]
+			if !ok {
+				continue
+			}
+			n := len(ls) * len(rs)
+			ks.Blocks++
+			ks.Pairs += n
+			if n > ks.MaxBlock {
+				ks.MaxBlock = n
+			}
+		}
+		for _, vals := range [][][][]string{leftVals, rightVals} {
+			for i := range vals {
+				if len(vals[i][k]) == 0 {
+					ks.Unblocked++
+				}
+			}
+		}
+		st.Keys[k] = ks
+	}
+
+	b.pairs(left, func(i int) [][]string { return leftVals[i] }, right, rightIdx, func(Pair) bool {
+		st.Pairs++
+		return true
+	})
+
+	return st
+}
+
+// dedupe removes blank and repeated values, keeping the order.  The values
+// returned by a KeyFunc may be shared, so a new slice is returned.
+func dedupe(vals []string) []string {
+	out := make([]string, 0, len(vals))
+	for _, v := range vals {
+		if v == "" {
+			continue
+		}
+		dupe := false
+		for _, o := range out {
+			if o == v {
+				dupe = true
+				break
+			}
+		}
+		if !dupe {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package blocking
+
+import (
+	"reflect"
+	"testing"
+)
+
+var left = []Record{
+	{ID: "l1", GivenName: "John", Surname: "Smith"},
+	{ID: "l2", GivenName: "Mary", Surname: "Schmidt"},
+	{ID: "l3", GivenName: "Ann", Surname: "Jones"},
+	{ID: "l4", GivenName: "Bob", Surname: ""},
+}
+
+var right = []Record{
+	{ID: "r1", GivenName: "Jon", Surname: "Smyth"},
+	{ID: "r2", GivenName: "Marie", Surname: "Schmitt"},
+	{ID: "r3", GivenName: "Anne", Surname: "Johns"},
+	{ID: "r4", GivenName: "Kate", Surname: "Smithe"},
+}
+
+func collect(b *Blocker) []string {
+	var out []string
+	b.Pairs(left, right, func(p Pair) bool {
+		out = append(out, p.Left.ID+"-"+p.Right.ID)
+		return true
+	})
+	return out
+}
+
+func TestPairs_SurnamePrimary(t *testing.T) {
+	want := []string{"l1-r1", "l1-r4", "l2-r2", "l3-r3"}
+	if got := collect(New(SurnamePrimary)); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestPairs_SurnameEither(t *testing.T) {
+	// smith has XMT as a secondary, so it meets schmidt
+	want := []string{"l1-r1", "l1-r2", "l1-r4", "l2-r1", "l2-r2", "l2-r4", "l3-r3"}
+	if got := collect(New(SurnameEither)); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestPairs_GivenInitialSurname(t *testing.T) {
+	want := []string{"l1-r1", "l2-r2", "l3-r3"}
+	if got := collect(New(GivenInitialSurname)); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestPairs_KeysAndStop(t *testing.T) {
+	b := New(SurnamePrimary, GivenInitialSurname)
+	var pairs []Pair
+	b.Pairs(left, right, func(p Pair) bool {
+		pairs = append(pairs, p)
+		return len(pairs) < 2
+	})
+	if len(pairs) != 2 {
+		t.Fatalf("wanted generation to stop after 2 pairs, got %v", len(pairs))
+	}
+	if want, got := []string{"surname-primary", "given-initial-surname"}, pairs[0].Keys; !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted keys %v, got %v", want, got)
+	}
+	if want, got := []string{"surname-primary"}, pairs[1].Keys; !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted keys %v, got %v", want, got)
+	}
+}
+
+func TestStats(t *testing.T) {
+	st := New(SurnamePrimary, SurnameSecondary).Stats(left, right)
+
+	if want, got := 16, st.CrossProduct; want != got {
+		t.Fatalf("cross product wanted %v, got %v", want, got)
+	}
+	if want, got := 4, st.Pairs; want != got {
+		t.Fatalf("pairs wanted %v, got %v", want, got)
+	}
+	if want, got := 0.75, st.ReductionRatio(); want != got {
+		t.Fatalf("reduction ratio wanted %v, got %v", want, got)
+	}
+
+	prim := st.Keys[0]
+	if prim.Blocks != 3 || prim.MaxBlock != 2 || prim.Pairs != 4 || prim.Unblocked != 1 {
+		t.Fatalf("unexpected primary stats %+v", prim)
+	}
+}
+
+func TestGivenInitialSurname(t *testing.T) {
+	e := New().encoder()
+	want := GivenInitialSurname.Func(e, Record{GivenName: "John", Surname: "Smith"})
+	if len(want) != 1 || want[0] != "J:SM0" {
+		t.Fatalf("wanted [J:SM0], got %v", want)
+	}
+	for _, given := range []string{"john", " John", "\tjohn", ".John", "-john", "'John", "  'j."} {
+		if got := GivenInitialSurname.Func(e, Record{GivenName: given, Surname: "Smith"}); !reflect.DeepEqual(want, got) {
+			t.Errorf("%q: wanted %v, got %v", given, want, got)
+		}
+	}
+	for _, given := range []string{"", " ", "-. '"} {
+		if got := GivenInitialSurname.Func(e, Record{GivenName: given, Surname: "Smith"}); got != nil {
+			t.Errorf("%q: wanted no key, got %v", given, got)
+		}
+	}
+}
+
+func TestDedupe_KeepsInput(t *testing.T) {
+	vals := []string{"", "A", "A", "B"}
+	if want, got := []string{"A", "B"}, dedupe(vals); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+	if want := []string{"", "A", "A", "B"}; !reflect.DeepEqual(want, vals) {
+		t.Fatalf("input was changed to %v", vals)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+/*
+#include <stdlib.h>
+#define METAPHONE3_NO_PROTOTYPES
+#include "metaphone3.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+// cache has a pool of encoders for each set of options
+var cache pools.Cache
+
+//export metaphone3_encode
+func metaphone3_encode(in *C.char, flags C.int, maxLength C.int, primary, secondary **C.char) C.int {
+	if in == nil || primary == nil || secondary == nil || flags&^C.METAPHONE3_ALL_FLAGS != 0 {
+		return C.METAPHONE3_ERR_INVALID
+	}
+	p, err := cache.Get(pools.Options{
+		Vowels:     flags&C.METAPHONE3_ENCODE_VOWELS != 0,
+		Exact:      flags&C.METAPHONE3_ENCODE_EXACT != 0,
+		FullLength: flags&C.METAPHONE3_FULL_LENGTH != 0,
+		MaxLength:  int(maxLength),
+	})
+	if err != nil {
+		return C.METAPHONE3_ERR_INVALID
+	}
+
+	prim, sec, err := p.TryEncode(C.GoString(in))
+	if err != nil {
+		return C.METAPHONE3_ERR_INTERNAL
+	}
+
+	*primary = C.CString(prim)
+	*secondary = C.CString(sec)
+	return C.METAPHONE3_OK
+}
+
+//export metaphone3_free
+func metaphone3_free(p *C.char) {
+	C.free(unsafe.Pointer(p))
+}
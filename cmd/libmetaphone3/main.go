@@ -0,0 +1,10 @@
+// Command libmetaphone3 is a C shared library of the encoder, so other languages get
+// exactly the same keys as Go.  Build it with:
+//
+//	go build -buildmode=c-shared -o libmetaphone3.so ./cmd/libmetaphone3
+//
+// and include metaphone3.h from this directory.  metaphone3_encode can be called from
+// any thread, encoders are pooled for each set of options.
+package main
+
+func main() {}
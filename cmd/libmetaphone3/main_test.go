@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestCLibrary builds the shared library and runs the C test program against it
+func TestCLibrary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the C library build in short mode")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("the C test program is only run on linux")
+	}
+	gcc, err := exec.LookPath("gcc")
+	if err != nil {
+		t.Skip("gcc not found")
+	}
+
+	dir := t.TempDir()
+	run := func(name string, args ...string) {
+		t.Helper()
+		cmd := exec.Command(name, args...)
+		cmd.Env = append(os.Environ(), "LD_LIBRARY_PATH="+dir)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%v %v: %v\n%s", name, args, err, out)
+		}
+	}
+
+	run("go", "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "libmetaphone3.so"), ".")
+	run(gcc, "-Wall", "-Werror", "-o", filepath.Join(dir, "metaphone3_test"), filepath.Join("testdata", "metaphone3_test.c"), "-I.", "-L"+dir, "-lmetaphone3")
+	run(filepath.Join(dir, "metaphone3_test"))
+}
@@ -0,0 +1,53 @@
+/*
+ * metaphone3.h - C interface to the Go Metaphone 3 encoder.
+ *
+ * Build the library with:
+ *
+ *	go build -buildmode=c-shared -o libmetaphone3.so ./cmd/libmetaphone3
+ *
+ * and link with -lmetaphone3.
+ */
+#ifndef METAPHONE3_H
+#define METAPHONE3_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Option flags for metaphone3_encode, they can be combined with | */
+#define METAPHONE3_ENCODE_VOWELS 1 /* encode non-initial vowels */
+#define METAPHONE3_ENCODE_EXACT  2 /* encode consonants as exactly as possible */
+#define METAPHONE3_FULL_LENGTH   4 /* ignore max_length and encode the whole input */
+#define METAPHONE3_ALL_FLAGS     7
+
+/* Return codes of metaphone3_encode */
+#define METAPHONE3_OK            0
+#define METAPHONE3_ERR_INVALID  -1 /* a NULL pointer, max_length out of range or unknown flag */
+#define METAPHONE3_ERR_INTERNAL -2 /* a bug in the encoder, please report the input */
+
+/* the Go side only uses the constants, cgo declares the functions itself */
+#ifndef METAPHONE3_NO_PROTOTYPES
+
+/*
+ * metaphone3_encode encodes the NUL terminated UTF-8 string in.  max_length limits
+ * the length of the keys, 0 for the default of 8, and can be at most 64 (use
+ * METAPHONE3_FULL_LENGTH for longer keys).
+ *
+ * On success the keys are returned in *primary and *secondary, *secondary is an empty
+ * string when there's only one key.  Both are allocated with malloc and must be freed by
+ * the caller with metaphone3_free (or free).  Nothing is allocated when it fails.
+ *
+ * It's safe to call from any thread.
+ */
+int metaphone3_encode(const char *in, int flags, int max_length, char **primary, char **secondary);
+
+/* metaphone3_free frees a key returned by metaphone3_encode */
+void metaphone3_free(char *p);
+
+#endif
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,59 @@
+/*
+ * Test program for libmetaphone3, run by TestCLibrary:
+ *
+ *	gcc -o metaphone3_test testdata/metaphone3_test.c -I. -L. -lmetaphone3
+ */
+#include <stdio.h>
+#include <string.h>
+#include "metaphone3.h"
+
+static int failures = 0;
+
+static void check(const char *in, int flags, int max_length, const char *want_prim, const char *want_sec) {
+	char *prim = NULL, *sec = NULL;
+	int rc = metaphone3_encode(in, flags, max_length, &prim, &sec);
+	if (rc != METAPHONE3_OK) {
+		printf("FAIL %s: return code %d\n", in, rc);
+		failures++;
+		return;
+	}
+	if (strcmp(prim, want_prim) != 0 || strcmp(sec, want_sec) != 0) {
+		printf("FAIL %s flags=%d max_length=%d: wanted %s/%s, got %s/%s\n", in, flags, max_length, want_prim, want_sec, prim, sec);
+		failures++;
+	}
+	metaphone3_free(prim);
+	metaphone3_free(sec);
+}
+
+static void check_invalid(const char *name, int rc) {
+	if (rc != METAPHONE3_ERR_INVALID) {
+		printf("FAIL %s: wanted METAPHONE3_ERR_INVALID, got %d\n", name, rc);
+		failures++;
+	}
+}
+
+int main(void) {
+	char *prim, *sec;
+
+	check("Smith", 0, 0, "SM0", "XMT");
+	check("Schmidt", 0, 0, "XMT", "");
+	check("", 0, 0, "", "");
+	check("Villafranca", METAPHONE3_ENCODE_VOWELS, 4, "FALA", "FAFR");
+	check("Villafranca", METAPHONE3_ENCODE_VOWELS | METAPHONE3_ENCODE_EXACT, 4, "VALA", "VAFR");
+	check("Alexandropavlovskov", METAPHONE3_FULL_LENGTH, 0, "ALKSNTRPFLFSKF", "");
+	check("Müller", 0, 0, "MLR", "");
+	check("Smith", 0, 64, "SM0", "XMT");
+
+	check_invalid("NULL input", metaphone3_encode(NULL, 0, 0, &prim, &sec));
+	check_invalid("NULL primary", metaphone3_encode("Smith", 0, 0, NULL, &sec));
+	check_invalid("negative max_length", metaphone3_encode("Smith", 0, -1, &prim, &sec));
+	check_invalid("max_length over 64", metaphone3_encode("Smith", 0, 65, &prim, &sec));
+	check_invalid("unknown flag", metaphone3_encode("Smith", 8, 0, &prim, &sec));
+
+	if (failures > 0) {
+		printf("%d failures\n", failures);
+		return 1;
+	}
+	printf("ok\n");
+	return 0;
+}
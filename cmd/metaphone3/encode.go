@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dlclark/metaphone3"
+)
+
+// errUsage is returned when the flags can't be parsed, the flag package has already
+// reported the problem
+var errUsage = errors.New("usage")
+
+// encoderFlags are the encoder options shared by the subcommands
+type encoderFlags struct {
+	vowels, exact, full bool
+	maxLength           int
+}
+
+func (f *encoderFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.vowels, "vowels", false, "encode non-initial vowels")
+	fs.BoolVar(&f.exact, "exact", false, "encode consonants as exactly as possible")
+	fs.IntVar(&f.maxLength, "max-length", 8, "max length of the keys")
+	fs.BoolVar(&f.full, "full-length", false, "don't limit the length of the keys")
+}
+
+func (f *encoderFlags) encoder() *metaphone3.Encoder {
+	return &metaphone3.Encoder{
+		EncodeVowels: f.vowels,
+		EncodeExact:  f.exact,
+		MaxLength:    f.maxLength,
+		FullLength:   f.full,
+	}
+}
+
+// openInput returns the named file, or stdin if the name is blank or "-"
+func openInput(name string, stdin io.Reader) (io.ReadCloser, error) {
+	if name == "" || name == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(name)
+}
+
+// readValues calls fn for each value of the input, either each line or each value
+// of the named column if the input is CSV
+func readValues(r io.Reader, column string, fn func(string) error) error {
+	if column == "" {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if err := fn(scanner.Text()); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	col := -1
+	for i, h := range header {
+		if h == column {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return fmt.Errorf("column %q not found", column)
+	}
+
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		v := ""
+		if col < len(line) {
+			v = line[col]
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+}
+
+func runEncode(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("encode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var ef encoderFlags
+	ef.register(fs)
+	input := fs.String("input", "", "read from file instead of stdin")
+	column := fs.String("column", "", "read CSV input and encode the named column")
+	format := fs.String("format", "tsv", "output format: tsv, csv or jsonl")
+	header := fs.Bool("header", false, "write a header row for tsv and csv output")
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	bw := bufio.NewWriter(stdout)
+	out, err := newRecordWriter(*format, bw, []string{"value", "primary", "secondary"}, *header)
+	if err != nil {
+		return err
+	}
+
+	e := ef.encoder()
+	encode := func(v string) error {
+		prim, sec := e.Encode(v)
+		return out.Write([]string{v, prim, sec})
+	}
+
+	if fs.NArg() > 0 {
+		for _, v := range fs.Args() {
+			if err := encode(v); err != nil {
+				return err
+			}
+		}
+	} else {
+		in, err := openInput(*input, stdin)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		if err := readValues(in, *column, encode); err != nil {
+			return err
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dlclark/metaphone3/enrich"
+)
+
+func runEnrich(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("enrich", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var ef encoderFlags
+	ef.register(fs)
+	columns := fs.String("columns", "", "comma separated names of the columns to encode")
+	input := fs.String("input", "", "read from file instead of stdin")
+	output := fs.String("output", "", "write to file instead of stdout")
+	format := fs.String("format", "", "file format: csv or parquet (default from the --input extension, or csv)")
+	workers := fs.Int("workers", 0, "number of goroutines encoding, 0 for one per CPU")
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *columns == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if *format == "" {
+		*format = "csv"
+		if strings.EqualFold(filepath.Ext(*input), ".parquet") {
+			*format = "parquet"
+		}
+	}
+	if *format != "csv" && *format != "parquet" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	o := enrich.Options{
+		Columns: strings.Split(*columns, ","),
+		Encoder: ef.encoder(),
+		Workers: *workers,
+	}
+
+	if *output == "" || *output == "-" {
+		bw := bufio.NewWriter(stdout)
+		if err := enrichInput(*format, *input, stdin, bw, o); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	// write next to the output and rename it when done, so a failure doesn't leave
+	// a partial file behind
+	out, err := os.CreateTemp(filepath.Dir(*output), "."+filepath.Base(*output)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	bw := bufio.NewWriter(out)
+	if err := enrichInput(*format, *input, stdin, bw, o); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if err := out.Chmod(0644); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), *output)
+}
+
+// enrichInput enriches the named file, or stdin if the name is blank or "-".  Parquet
+// needs random access so Parquet from stdin is read into memory first.
+func enrichInput(format, name string, stdin io.Reader, w io.Writer, o enrich.Options) error {
+	if format == "csv" {
+		in, err := openInput(name, stdin)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		return enrich.CSV(in, w, o)
+	}
+
+	if name == "" || name == "-" {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		return enrich.Parquet(bytes.NewReader(b), int64(len(b)), w, o)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return enrich.Parquet(f, st.Size(), w, o)
+}
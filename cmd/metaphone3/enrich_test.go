@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestEnrich_CSV(t *testing.T) {
+	in := "id,surname\n1,Smith\n2,Schmidt\n"
+	want := "id,surname,surname_mp3_primary,surname_mp3_secondary\n1,Smith,SMA0,XMAT\n2,Schmidt,XMAT,\n"
+	if got := runCmd(t, in, "enrich", "--columns", "surname", "--vowels"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	p := writeTemp(t, "people.csv", in)
+	out := filepath.Join(filepath.Dir(p), "out.csv")
+	runCmd(t, "", "enrich", "--columns", "surname", "--vowels", "--input", p, "--output", out)
+	if got, err := os.ReadFile(out); err != nil || string(got) != want {
+		t.Fatalf("wanted %q, got %q %v", want, got, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"enrich"}, strings.NewReader(in), &stdout, &stderr); code != 2 {
+		t.Fatalf("wanted exit code 2 without --columns, got %v", code)
+	}
+	if code := run([]string{"enrich", "--columns", "name"}, strings.NewReader(in), &stdout, &stderr); code != 1 {
+		t.Fatalf("wanted exit code 1 for a missing column, got %v", code)
+	}
+
+	// a failure leaves no output file behind, not even a partial one
+	failed := filepath.Join(filepath.Dir(p), "failed.csv")
+	if code := run([]string{"enrich", "--columns", "name", "--input", p, "--output", failed}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("wanted exit code 1 for a missing column, got %v", code)
+	}
+	if files, _ := os.ReadDir(filepath.Dir(p)); len(files) != 2 {
+		t.Fatalf("wanted only the input and output files, got %v", files)
+	}
+}
+
+func TestEnrich_Parquet(t *testing.T) {
+	type row struct {
+		Surname string `parquet:"surname"`
+	}
+	p := filepath.Join(t.TempDir(), "people.parquet")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := parquet.NewGenericWriter[row](f)
+	w.Write([]row{{"Smith"}, {"Schmidt"}})
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	got := runCmd(t, "", "enrich", "--columns", "surname", "--input", p)
+	rows, err := parquet.Read[struct {
+		Surname   string `parquet:"surname"`
+		Primary   string `parquet:"surname_mp3_primary"`
+		Secondary string `parquet:"surname_mp3_secondary"`
+	}](strings.NewReader(got), int64(len(got)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Primary != "SM0" || rows[0].Secondary != "XMT" || rows[1].Primary != "XMT" {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+}
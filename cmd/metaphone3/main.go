@@ -0,0 +1,86 @@
+// Command metaphone3 encodes words and names with the Metaphone 3 algorithm.
+//
+// Usage:
+//
+//	metaphone3 [encode] [flags] [word ...]
+//	metaphone3 match --against file [flags] word ...
+//	metaphone3 cluster [flags] [file]
+//	metaphone3 enrich --columns name[,name] [flags]
+//
+// Words are encoded from the arguments, or from each line of the input when there are
+// no arguments.  With --column the input is read as CSV with a header row and the
+// named column is encoded.  Each value is written with its primary and secondary keys.
+//
+// Flags:
+//
+//	--vowels          encode non-initial vowels
+//	--exact           encode consonants as exactly as possible
+//	--max-length n    max length of the keys (default 8)
+//	--full-length     don't limit the length of the keys
+//	--input file      read from file instead of stdin
+//	--column name     read CSV input and encode the named column
+//	--format f        output format: tsv, csv or jsonl (default tsv)
+//	--header          write a header row for tsv and csv output
+//
+// The match subcommand indexes the values of the --against file (lines, or a CSV column
+// with --column) and writes the values that sound like each word.  Primary key matches
+// are listed before secondary matches and the most frequent values come first.
+// --limit caps the number of matches per word.
+//
+// The cluster subcommand groups the values of the file (or stdin) that share a key and
+// writes each group of at least --min-size distinct values (default 2), largest groups
+// first.  A value with a secondary key can be in two groups.
+//
+// The enrich subcommand copies a CSV (with a header row) or Parquet file from --input
+// (or stdin) to --output (or stdout), adding NAME_mp3_primary and NAME_mp3_secondary
+// columns for each of the --columns.  The rows are encoded in parallel on --workers
+// goroutines and written in their original order.  --format is csv or parquet, by
+// default it's parquet if the --input file has a .parquet extension.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cmd := "encode"
+	if len(args) > 0 {
+		switch args[0] {
+		case "encode", "match", "cluster", "enrich":
+			cmd, args = args[0], args[1:]
+		case "help", "-h", "--help", "-help":
+			fmt.Fprintln(stderr, "usage: metaphone3 [encode] [flags] [word ...]")
+			fmt.Fprintln(stderr, "       metaphone3 match --against file [flags] word ...")
+			fmt.Fprintln(stderr, "       metaphone3 cluster [flags] [file]")
+			fmt.Fprintln(stderr, "       metaphone3 enrich --columns name[,name] [flags]")
+			return 0
+		}
+	}
+
+	var err error
+	switch cmd {
+	case "encode":
+		err = runEncode(args, stdin, stdout, stderr)
+	case "match":
+		err = runMatch(args, stdin, stdout, stderr)
+	case "cluster":
+		err = runCluster(args, stdin, stdout, stderr)
+	case "enrich":
+		err = runEnrich(args, stdin, stdout, stderr)
+	}
+
+	if err == errUsage {
+		return 2
+	} else if err != nil {
+		fmt.Fprintf(stderr, "metaphone3 %v: %v\n", cmd, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func runCmd(t *testing.T, stdin string, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	if code := run(args, strings.NewReader(stdin), &stdout, &stderr); code != 0 {
+		t.Fatalf("run %v exit code %v: %v", args, code, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestEncode_Args(t *testing.T) {
+	want := "Smith\tSM0\tXMT\nSchmidt\tXMT\t\n"
+	if got := runCmd(t, "", "Smith", "Schmidt"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+	if got := runCmd(t, "", "encode", "Smith", "Schmidt"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+}
+
+func TestEncode_Options(t *testing.T) {
+	want := "value,primary,secondary\nsupernode,SAPARNAT,\n"
+	if got := runCmd(t, "", "--vowels", "--format", "csv", "--header", "supernode"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	want = "Villafranca\tFLFR\tFFRN\n"
+	if got := runCmd(t, "", "--max-length", "4", "Villafranca"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	want = "Alexandropavlovskov\tALKSNTRPFLFSKF\t\n"
+	if got := runCmd(t, "", "--full-length", "Alexandropavlovskov"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+}
+
+func TestEncode_Stdin(t *testing.T) {
+	want := `{"value":"Smith","primary":"SM0","secondary":"XMT"}` + "\n" +
+		`{"value":"ache","primary":"AK","secondary":"AX"}` + "\n"
+	if got := runCmd(t, "Smith\nache\n", "--format", "jsonl"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+}
+
+func TestEncode_Column(t *testing.T) {
+	in := "id,given,surname\n1,John,Smith\n2,Mary,\"Schmidt\"\n"
+	want := "Smith\tSM0\tXMT\nSchmidt\tXMT\t\n"
+	if got := runCmd(t, in, "--column", "surname"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--column", "nope"}, strings.NewReader(in), &stdout, &stderr); code != 1 {
+		t.Fatalf("wanted exit code 1 for a missing column, got %v", code)
+	}
+}
+
+func TestEncode_BadFlags(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--format", "xml", "Smith"}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Fatalf("wanted exit code 1 for an unknown format, got %v", code)
+	}
+	if code := run([]string{"--nope"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Fatalf("wanted exit code 2 for an unknown flag, got %v", code)
+	}
+}
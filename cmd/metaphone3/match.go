@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"strconv"
+
+	"github.com/dlclark/metaphone3/index"
+)
+
+// loadIndex adds every value of the input to a new index
+func loadIndex(ef encoderFlags, name, column string, stdin io.Reader) (*index.Index, error) {
+	in, err := openInput(name, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	ix := index.New(ef.encoder())
+	err = readValues(in, column, func(v string) error {
+		ix.Add(v, "")
+		return nil
+	})
+	return ix, err
+}
+
+func runMatch(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("match", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var ef encoderFlags
+	ef.register(fs)
+	against := fs.String("against", "", "file of values to search")
+	column := fs.String("column", "", "read the --against file as CSV and index the named column")
+	limit := fs.Int("limit", 0, "max matches per word, 0 for all")
+	format := fs.String("format", "tsv", "output format: tsv, csv or jsonl")
+	header := fs.Bool("header", false, "write a header row for tsv and csv output")
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *against == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	ix, err := loadIndex(ef, *against, *column, stdin)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(stdout)
+	out, err := newRecordWriter(*format, bw, []string{"word", "match", "type", "count", "primary", "secondary"}, *header)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range fs.Args() {
+		ms := ix.Search(w)
+		if *limit > 0 && len(ms) > *limit {
+			ms = ms[:*limit]
+		}
+		for _, m := range ms {
+			if err := writeMatch(out, w, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func runCluster(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("cluster", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var ef encoderFlags
+	ef.register(fs)
+	column := fs.String("column", "", "read CSV input and cluster the named column")
+	minSize := fs.Int("min-size", 2, "min number of distinct values in a cluster")
+	format := fs.String("format", "tsv", "output format: tsv, csv or jsonl")
+	header := fs.Bool("header", false, "write a header row for tsv and csv output")
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	ix, err := loadIndex(ef, fs.Arg(0), *column, stdin)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(stdout)
+	out, err := newRecordWriter(*format, bw, []string{"key", "value", "type", "count", "primary", "secondary"}, *header)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range ix.Clusters(*minSize) {
+		for _, m := range c.Members {
+			if err := writeMatch(out, c.Key, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeMatch(out recordWriter, first string, m index.Match) error {
+	ent := m.Entry
+	return out.Write([]string{first, ent.Value, m.Type.String(), strconv.Itoa(ent.Count), ent.Primary, ent.Secondary})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSurnames = "Smith\nSmyth\nSmith\nSchmidt\nJones\nSmith\n"
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestMatch(t *testing.T) {
+	p := writeTemp(t, "surnames.txt", testSurnames)
+
+	want := "Smythe\tSmith\tprimary\t3\tSM0\tXMT\n" +
+		"Smythe\tSmyth\tprimary\t1\tSM0\tXMT\n" +
+		"Smythe\tSchmidt\tsecondary\t1\tXMT\t\n"
+	if got := runCmd(t, "", "match", "--against", p, "Smythe"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	want = "Schmitt\tSchmidt\tprimary\t1\tXMT\t\n"
+	if got := runCmd(t, "", "match", "--against", p, "--limit", "1", "Schmitt"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"match", "Smith"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Fatalf("wanted exit code 2 without --against, got %v", code)
+	}
+}
+
+func TestCluster(t *testing.T) {
+	p := writeTemp(t, "names.csv", "id,surname\n1,Smith\n2,Smyth\n3,Jones\n4,Smith\n")
+
+	want := "key,value,type,count,primary,secondary\n" +
+		"SM0,Smith,primary,2,SM0,XMT\n" +
+		"SM0,Smyth,primary,1,SM0,XMT\n" +
+		"XMT,Smith,secondary,2,SM0,XMT\n" +
+		"XMT,Smyth,secondary,1,SM0,XMT\n"
+	if got := runCmd(t, "", "cluster", "--column", "surname", "--format", "csv", "--header", p); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	want = "XMT\tSchmidt\tprimary\t1\tXMT\t\n" +
+		"XMT\tSmith\tsecondary\t3\tSM0\tXMT\n" +
+		"XMT\tSmyth\tsecondary\t1\tSM0\tXMT\n" +
+		"SM0\tSmith\tprimary\t3\tSM0\tXMT\n" +
+		"SM0\tSmyth\tprimary\t1\tSM0\tXMT\n"
+	if got := runCmd(t, testSurnames, "cluster"); got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// recordWriter writes rows of named fields in one of the output formats
+type recordWriter interface {
+	Write(fields []string) error
+	Flush() error
+}
+
+func newRecordWriter(format string, w io.Writer, names []string, header bool) (recordWriter, error) {
+	var out recordWriter
+	switch format {
+	case "tsv":
+		out = &tsvWriter{w: w}
+	case "csv":
+		out = &csvWriter{w: csv.NewWriter(w)}
+	case "jsonl":
+		return &jsonlWriter{enc: json.NewEncoder(w), names: names}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	if header {
+		if err := out.Write(names); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+type tsvWriter struct {
+	w io.Writer
+}
+
+// tabs and newlines in values are replaced by spaces to keep the columns intact
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+func (t *tsvWriter) Write(fields []string) error {
+	for i, f := range fields {
+		if i > 0 {
+			if _, err := io.WriteString(t.w, "\t"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(t.w, tsvReplacer.Replace(f)); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(t.w, "\n")
+	return err
+}
+
+func (t *tsvWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(fields []string) error {
+	return c.w.Write(fields)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlWriter writes each row as a JSON object keyed by the field names
+type jsonlWriter struct {
+	enc   *json.Encoder
+	names []string
+}
+
+func (j *jsonlWriter) Write(fields []string) error {
+	// build the object by hand to keep the field order
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		k, _ := json.Marshal(j.names[i])
+		v, _ := json.Marshal(f)
+		sb.Write(k)
+		sb.WriteByte(':')
+		sb.Write(v)
+	}
+	sb.WriteByte('}')
+	return j.enc.Encode(json.RawMessage(sb.String()))
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
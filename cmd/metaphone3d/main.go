@@ -0,0 +1,126 @@
+// Command metaphone3d serves Metaphone 3 encoding over HTTP with JSON requests and
+// responses, so other services get the exact same keys as Go code using the package.
+//
+// Usage:
+//
+//	metaphone3d [--addr :8080] [--grpc-addr :9090] [--resp-addr :6379] [--max-body 1048576] [--max-batch 1000]
+//
+// Endpoints:
+//
+//	POST /encode        {"value": "Smith", "options": {...}}
+//	POST /encode/batch  {"values": ["Smith", "Schmidt"], "options": {...}}
+//	POST /match         {"value": "Smyth", "candidates": ["Smith", "Schmidt"], "limit": 10, "options": {...}}
+//	GET  /healthz
+//	GET  /metrics       Prometheus text format
+//
+// The options are all optional: {"vowels": true, "exact": true, "max_length": 8,
+// "full_length": false, "input": "skip|strip|separate|digits|reject"}.  Errors are
+// returned as {"error": "..."} with a 4xx or 5xx status.
+//
+// With --grpc-addr the Metaphone3 gRPC service from proto/metaphone3/v1 is served too,
+// and with --resp-addr the Redis protocol commands from the respserver package.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/dlclark/metaphone3/grpcserver"
+	"github.com/dlclark/metaphone3/respserver"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	grpcAddr := flag.String("grpc-addr", "", "address to serve the gRPC service on, blank to not serve it")
+	respAddr := flag.String("resp-addr", "", "address to serve the Redis protocol commands on, blank to not serve them")
+	maxBody := flag.Int64("max-body", 1<<20, "max size of a request body in bytes")
+	maxBatch := flag.Int("max-batch", 1000, "max number of values in a batch or candidates in a match")
+	respKeys := flag.Int("resp-max-keys", 1024, "max number of RESP indexes, 0 for no limit")
+	respNames := flag.Int("resp-max-names", 1<<20, "max number of names in each RESP index, 0 for no limit")
+	respIDs := flag.Int("resp-max-ids", 1024, "max number of ids kept for each name in a RESP index, 0 for no limit")
+	flag.Parse()
+
+	handler := newServer(config{maxBody: *maxBody, maxBatch: *maxBatch})
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var gs *grpc.Server
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		gs = grpc.NewServer()
+		// encodes over gRPC and RESP are counted in /metrics along with HTTP
+		g := grpcserver.Register(gs)
+		g.MaxCandidates = *maxBatch
+		g.Stats = handler.metrics.stats
+
+		log.Printf("metaphone3d gRPC listening on %v", *grpcAddr)
+		go func() {
+			if err := gs.Serve(lis); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	var rs *respserver.Server
+	if *respAddr != "" {
+		lis, err := net.Listen("tcp", *respAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rs = respserver.New(&metaphone3.Encoder{Stats: handler.metrics.stats})
+		rs.MaxKeys, rs.MaxNames, rs.MaxIDs = *respKeys, *respNames, *respIDs
+
+		log.Printf("metaphone3d RESP listening on %v", *respAddr)
+		go func() {
+			if err := rs.Serve(lis); !errors.Is(err, respserver.ErrServerClosed) {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	// closed once the in-flight requests are drained, ListenAndServe returns as
+	// soon as Shutdown is called
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		if gs != nil {
+			gs.GracefulStop()
+		}
+		if rs != nil {
+			rs.Close()
+		}
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdown); err != nil {
+			log.Printf("metaphone3d shutdown: %v", err)
+		}
+	}()
+
+	log.Printf("metaphone3d listening on %v", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-done
+}
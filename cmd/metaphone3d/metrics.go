@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlclark/metaphone3"
+)
+
+// paths that get their own metrics label, anything else is counted as "other"
+var metricPaths = map[string]bool{
+	"/encode":       true,
+	"/encode/batch": true,
+	"/match":        true,
+	"/healthz":      true,
+	"/metrics":      true,
+}
+
+type requestLabels struct {
+	path string
+	code int
+}
+
+// metrics are kept by hand and written in the Prometheus text format
+type metrics struct {
+	inFlight atomic.Int64
+	// values encoded and values rejected by the input policy
+	values, invalid atomic.Int64
+	// stats of all the encoders
+	stats *metaphone3.Stats
+
+	mu       sync.Mutex
+	requests map[requestLabels]int64
+	// request durations by path
+	seconds map[string]float64
+	count   map[string]int64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests: make(map[requestLabels]int64),
+		seconds:  make(map[string]float64),
+		count:    make(map[string]int64),
+		stats:    metaphone3.NewStats(),
+	}
+}
+
+func (m *metrics) observe(path string, code int, d time.Duration) {
+	if !metricPaths[path] {
+		path = "other"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestLabels{path, code}]++
+	m.seconds[path] += d.Seconds()
+	m.count[path]++
+}
+
+func (m *metrics) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	m.mu.Lock()
+	labels := make([]requestLabels, 0, len(m.requests))
+	for l := range m.requests {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].path != labels[j].path {
+			return labels[i].path < labels[j].path
+		}
+		return labels[i].code < labels[j].code
+	})
+	paths := make([]string, 0, len(m.count))
+	for p := range m.count {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	fmt.Fprintln(w, "# HELP metaphone3d_requests_total Requests by path and status code.")
+	fmt.Fprintln(w, "# TYPE metaphone3d_requests_total counter")
+	for _, l := range labels {
+		fmt.Fprintf(w, "metaphone3d_requests_total{path=%q,code=\"%d\"} %d\n", l.path, l.code, m.requests[l])
+	}
+	fmt.Fprintln(w, "# HELP metaphone3d_request_duration_seconds Time spent serving requests by path.")
+	fmt.Fprintln(w, "# TYPE metaphone3d_request_duration_seconds summary")
+	for _, p := range paths {
+		fmt.Fprintf(w, "metaphone3d_request_duration_seconds_sum{path=%q} %g\n", p, m.seconds[p])
+		fmt.Fprintf(w, "metaphone3d_request_duration_seconds_count{path=%q} %d\n", p, m.count[p])
+	}
+	m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP metaphone3d_requests_in_flight Requests being served.")
+	fmt.Fprintln(w, "# TYPE metaphone3d_requests_in_flight gauge")
+	fmt.Fprintf(w, "metaphone3d_requests_in_flight %d\n", m.inFlight.Load())
+	fmt.Fprintln(w, "# HELP metaphone3d_values_encoded_total Values encoded.")
+	fmt.Fprintln(w, "# TYPE metaphone3d_values_encoded_total counter")
+	fmt.Fprintf(w, "metaphone3d_values_encoded_total %d\n", m.values.Load())
+	fmt.Fprintln(w, "# HELP metaphone3d_values_rejected_total Values rejected by the input policy.")
+	fmt.Fprintln(w, "# TYPE metaphone3d_values_rejected_total counter")
+	fmt.Fprintf(w, "metaphone3d_values_rejected_total %d\n", m.invalid.Load())
+	m.stats.WritePrometheus(w)
+}
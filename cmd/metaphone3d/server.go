@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/dlclark/metaphone3/index"
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+type config struct {
+	// maxBody is the max size of a request body in bytes
+	maxBody int64
+	// maxBatch is the max number of values in a batch or candidates in a match
+	maxBatch int
+}
+
+// options are the encoder options of a request
+type options struct {
+	Vowels     bool   `json:"vowels"`
+	Exact      bool   `json:"exact"`
+	MaxLength  int    `json:"max_length"`
+	FullLength bool   `json:"full_length"`
+	Input      string `json:"input"`
+}
+
+type encodeRequest struct {
+	Value   string  `json:"value"`
+	Options options `json:"options"`
+}
+
+type batchRequest struct {
+	Values  []string `json:"values"`
+	Options options  `json:"options"`
+}
+
+type matchRequest struct {
+	Value      string   `json:"value"`
+	Candidates []string `json:"candidates"`
+	Limit      int      `json:"limit"`
+	Options    options  `json:"options"`
+}
+
+type result struct {
+	Value     string `json:"value"`
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Error     string `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Results []result `json:"results"`
+}
+
+type match struct {
+	Value     string `json:"value"`
+	Type      string `json:"type"`
+	Count     int    `json:"count"`
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+}
+
+type matchResponse struct {
+	result
+	Matches []match `json:"matches"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// httpError is an error with the status code to respond with
+type httpError struct {
+	code int
+	msg  string
+}
+
+func (e *httpError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...any) error {
+	return &httpError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+type server struct {
+	cfg     config
+	mux     *http.ServeMux
+	metrics *metrics
+	pools   pools.Cache
+}
+
+func newServer(cfg config) *server {
+	s := &server{cfg: cfg, mux: http.NewServeMux(), metrics: newMetrics()}
+	s.mux.HandleFunc("/encode", post(s, s.handleEncode))
+	s.mux.HandleFunc("/encode/batch", post(s, s.handleBatch))
+	s.mux.HandleFunc("/match", post(s, s.handleMatch))
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/metrics", s.metrics.handle)
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	s.metrics.inFlight.Add(1)
+	defer s.metrics.inFlight.Add(-1)
+
+	sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+	s.mux.ServeHTTP(sw, r)
+	s.metrics.observe(r.URL.Path, sw.code, time.Since(start))
+}
+
+// statusWriter records the status code of a response for the metrics
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// post wraps a JSON endpoint, it decodes the request body into a new *T and
+// writes the response or error
+func post[T any](s *server, fn func(*T) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"method not allowed"})
+			return
+		}
+
+		req := new(T)
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.cfg.maxBody))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(req); err != nil {
+			var tooBig *http.MaxBytesError
+			if errors.As(err, &tooBig) {
+				writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{fmt.Sprintf("request body is larger than %v bytes", s.cfg.maxBody)})
+			} else {
+				writeJSON(w, http.StatusBadRequest, errorResponse{"invalid JSON: " + err.Error()})
+			}
+			return
+		}
+
+		resp, err := fn(req)
+		if err != nil {
+			code := http.StatusInternalServerError
+			var he *httpError
+			if errors.As(err, &he) {
+				code = he.code
+			}
+			writeJSON(w, code, errorResponse{err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// pool returns the pool of encoders for the options of a request
+func (s *server) pool(o options) (*metaphone3.Pool, error) {
+	input := metaphone3.InputSkip
+	if o.Input != "" {
+		var err error
+		if input, err = metaphone3.ParseInputPolicy(o.Input); err != nil {
+			return nil, badRequest("unknown input %q", o.Input)
+		}
+	}
+
+	p, err := s.pools.Get(pools.Options{
+		Vowels:     o.Vowels,
+		Exact:      o.Exact,
+		MaxLength:  o.MaxLength,
+		FullLength: o.FullLength,
+		Input:      input,
+		Stats:      s.metrics.stats,
+	})
+	if err != nil {
+		return nil, badRequest("max_length must be between 0 and %v, use full_length for longer keys", pools.MaxLength)
+	}
+	return p, nil
+}
+
+// encode encodes one value, errors for invalid input are returned in the result
+func (s *server) encode(p *metaphone3.Pool, value string) (result, error) {
+	prim, sec, err := p.TryEncode(value)
+	s.metrics.values.Add(1)
+	if errors.Is(err, metaphone3.ErrNonLetter) {
+		s.metrics.invalid.Add(1)
+		return result{Value: value, Error: err.Error()}, nil
+	} else if err != nil {
+		return result{}, err
+	}
+	return result{Value: value, Primary: prim, Secondary: sec}, nil
+}
+
+func (s *server) handleEncode(req *encodeRequest) (any, error) {
+	p, err := s.pool(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.encode(p, req.Value)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, &httpError{http.StatusUnprocessableEntity, res.Error}
+	}
+	return res, nil
+}
+
+func (s *server) handleBatch(req *batchRequest) (any, error) {
+	if len(req.Values) > s.cfg.maxBatch {
+		return nil, &httpError{http.StatusRequestEntityTooLarge, fmt.Sprintf("batch has more than %v values", s.cfg.maxBatch)}
+	}
+	p, err := s.pool(req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := batchResponse{Results: make([]result, len(req.Values))}
+	for i, v := range req.Values {
+		if resp.Results[i], err = s.encode(p, v); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (s *server) handleMatch(req *matchRequest) (any, error) {
+	if len(req.Candidates) > s.cfg.maxBatch {
+		return nil, &httpError{http.StatusRequestEntityTooLarge, fmt.Sprintf("match has more than %v candidates", s.cfg.maxBatch)}
+	}
+	if req.Limit < 0 {
+		return nil, badRequest("limit can't be negative")
+	}
+	p, err := s.pool(req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.encode(p, req.Value)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, &httpError{http.StatusUnprocessableEntity, res.Error}
+	}
+
+	e := p.Get()
+	defer p.Put(e)
+	ix := index.New(e)
+	for _, c := range req.Candidates {
+		ix.Add(c, "")
+	}
+	s.metrics.values.Add(int64(len(req.Candidates)))
+
+	ms := ix.Search(req.Value)
+	if req.Limit > 0 && len(ms) > req.Limit {
+		ms = ms[:req.Limit]
+	}
+
+	resp := matchResponse{result: res, Matches: make([]match, len(ms))}
+	for i, m := range ms {
+		resp.Matches[i] = match{
+			Value:     m.Entry.Value,
+			Type:      m.Type.String(),
+			Count:     m.Entry.Count,
+			Primary:   m.Entry.Primary,
+			Secondary: m.Entry.Secondary,
+		}
+	}
+	return resp, nil
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(newServer(config{maxBody: 1024, maxBatch: 3}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// postJSON posts the body and decodes the response into out, returning the status code
+func postJSON(t *testing.T, ts *httptest.Server, path, body string, out any) int {
+	t.Helper()
+	resp, err := http.Post(ts.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("%v: wanted a JSON response, got %q", path, ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("%v: %v", path, err)
+	}
+	return resp.StatusCode
+}
+
+func TestEncode(t *testing.T) {
+	ts := newTestServer(t)
+
+	var res result
+	if code := postJSON(t, ts, "/encode", `{"value":"Smith"}`, &res); code != http.StatusOK {
+		t.Fatalf("wanted 200, got %v", code)
+	}
+	if want := (result{Value: "Smith", Primary: "SM0", Secondary: "XMT"}); res != want {
+		t.Fatalf("wanted %v, got %v", want, res)
+	}
+
+	res = result{}
+	postJSON(t, ts, "/encode", `{"value":"Villafranca","options":{"vowels":true,"exact":true,"max_length":4}}`, &res)
+	if want := (result{Value: "Villafranca", Primary: "VALA", Secondary: "VAFR"}); res != want {
+		t.Fatalf("wanted %v, got %v", want, res)
+	}
+
+	res = result{}
+	postJSON(t, ts, "/encode", `{"value":"Smith-Jones","options":{"input":"separate"}}`, &res)
+	if want := (result{Value: "Smith-Jones", Primary: "SM0JNS", Secondary: "XMTANS"}); res != want {
+		t.Fatalf("wanted %v, got %v", want, res)
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"value":`, http.StatusBadRequest},
+		{`{"value":"Smith","nope":1}`, http.StatusBadRequest},
+		{`{"value":"Smith","options":{"max_length":-1}}`, http.StatusBadRequest},
+		{`{"value":"Smith","options":{"max_length":65}}`, http.StatusBadRequest},
+		{`{"value":"Smith","options":{"input":"nope"}}`, http.StatusBadRequest},
+		{`{"value":"3M","options":{"input":"reject"}}`, http.StatusUnprocessableEntity},
+		{`{"value":"` + strings.Repeat("A", 2000) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, test := range tests {
+		var res errorResponse
+		if code := postJSON(t, ts, "/encode", test.body, &res); code != test.code {
+			t.Errorf("%.40v: wanted %v, got %v", test.body, test.code, code)
+		}
+		if res.Error == "" {
+			t.Errorf("%.40v: wanted an error message", test.body)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/encode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("wanted 405 for GET, got %v", resp.StatusCode)
+	}
+}
+
+func TestEncodeBatch(t *testing.T) {
+	ts := newTestServer(t)
+
+	var res batchResponse
+	if code := postJSON(t, ts, "/encode/batch", `{"values":["Smith","3M",""],"options":{"input":"reject"}}`, &res); code != http.StatusOK {
+		t.Fatalf("wanted 200, got %v", code)
+	}
+	if len(res.Results) != 3 {
+		t.Fatalf("wanted 3 results, got %v", res.Results)
+	}
+	if want := (result{Value: "Smith", Primary: "SM0", Secondary: "XMT"}); res.Results[0] != want {
+		t.Fatalf("wanted %v, got %v", want, res.Results[0])
+	}
+	if res.Results[1].Error == "" {
+		t.Fatalf("wanted an error for 3M, got %v", res.Results[1])
+	}
+	if want := (result{}); res.Results[2] != want {
+		t.Fatalf("wanted %v, got %v", want, res.Results[2])
+	}
+
+	var errRes errorResponse
+	if code := postJSON(t, ts, "/encode/batch", `{"values":["a","b","c","d"]}`, &errRes); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("wanted 413 for a large batch, got %v", code)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	ts := newTestServer(t)
+
+	var res matchResponse
+	if code := postJSON(t, ts, "/match", `{"value":"Smythe","candidates":["Schmidt","Smith","Jones"]}`, &res); code != http.StatusOK {
+		t.Fatalf("wanted 200, got %v", code)
+	}
+	want := []match{
+		{Value: "Smith", Type: "primary", Count: 1, Primary: "SM0", Secondary: "XMT"},
+		{Value: "Schmidt", Type: "secondary", Count: 1, Primary: "XMT"},
+	}
+	if !reflect.DeepEqual(want, res.Matches) {
+		t.Fatalf("wanted %v, got %v", want, res.Matches)
+	}
+	if res.Primary != "SM0" {
+		t.Fatalf("wanted the keys of the value, got %v", res.result)
+	}
+
+	res = matchResponse{}
+	postJSON(t, ts, "/match", `{"value":"Smythe","candidates":["Schmidt","Smith"],"limit":1}`, &res)
+	if len(res.Matches) != 1 || res.Matches[0].Value != "Smith" {
+		t.Fatalf("wanted only Smith, got %v", res.Matches)
+	}
+}
+
+func TestHealthAndMetrics(t *testing.T) {
+	ts := newTestServer(t)
+
+	var health map[string]string
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	json.NewDecoder(resp.Body).Decode(&health)
+	resp.Body.Close()
+	if health["status"] != "ok" {
+		t.Fatalf("wanted ok, got %v", health)
+	}
+
+	var res batchResponse
+	postJSON(t, ts, "/encode/batch", `{"values":["Smith","Jones"]}`, &res)
+	postJSON(t, ts, "/encode", `{"value":"Smith","options":{"max_length":99}}`, &errorResponse{})
+
+	resp, err = http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	for _, want := range []string{
+		`metaphone3d_requests_total{path="/encode/batch",code="200"} 1`,
+		`metaphone3d_requests_total{path="/encode",code="400"} 1`,
+		`metaphone3d_requests_total{path="/healthz",code="200"} 1`,
+		`metaphone3d_values_encoded_total 2`,
+		`metaphone3d_request_duration_seconds_count{path="/encode/batch"} 1`,
+		`metaphone3_inputs_total 2`,
+		`metaphone3_alternates_total 2`,
+		`metaphone3_rule_fired_total{rule="encodeTh"} 1`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("wanted metrics to contain %q, got:\n%s", want, b)
+		}
+	}
+}
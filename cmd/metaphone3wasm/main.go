@@ -0,0 +1,51 @@
+// Command metaphone3wasm runs the encoder in WebAssembly, so browsers and other
+// WebAssembly hosts get exactly the same keys as Go.
+//
+// For JavaScript build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o metaphone3.wasm ./cmd/metaphone3wasm
+//
+// and run it with wasm_exec.js from $(go env GOROOT)/lib/wasm.  Once running it sets
+// globalThis.metaphone3.encode(value, options), which returns {primary, secondary},
+// or {error} if the options aren't valid.  The options have the same names as the
+// options of metaphone3d: vowels, exact, max_length (up to 64), full_length and input.
+//
+// For WASI build it as a reactor with:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o metaphone3.wasm ./cmd/metaphone3wasm
+//
+// It exports metaphone3_buffer(size), which returns a buffer of at least size bytes
+// for the input, and metaphone3_encode(length, flags, max_length), which encodes the
+// first length bytes of the buffer and writes the primary and secondary separated by
+// a tab back into the buffer, call metaphone3_buffer(0) again to read it.  It returns
+// the length of the result, -1 if the arguments aren't valid (e.g. max_length over
+// 64) or -2 if the encoder failed.  The flags are the same as the flags of libmetaphone3.
+package main
+
+import (
+	"fmt"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+// flags of metaphone3_encode, the same as in cmd/libmetaphone3/metaphone3.h
+const (
+	flagEncodeVowels = 1 << iota
+	flagEncodeExact
+	flagFullLength
+
+	allFlags = flagEncodeVowels | flagEncodeExact | flagFullLength
+)
+
+// cache has the encoders for each set of options
+var cache pools.Cache
+
+// pool returns the encoders for a set of options
+func pool(o pools.Options) (*metaphone3.Pool, error) {
+	p, err := cache.Get(o)
+	if err != nil {
+		return nil, fmt.Errorf("max_length must be between 0 and %v", pools.MaxLength)
+	}
+	return p, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"syscall/js"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+func main() {
+	js.Global().Set("metaphone3", map[string]any{
+		"encode": js.FuncOf(encode),
+	})
+	// keep running so the callback can be called
+	select {}
+}
+
+// encode is metaphone3.encode(value, options)
+func encode(this js.Value, args []js.Value) any {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return map[string]any{"error": "value must be a string"}
+	}
+	var o pools.Options
+	if len(args) > 1 {
+		var err error
+		if o, err = parseOptions(args[1]); err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+	}
+	p, err := pool(o)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+
+	prim, sec, err := p.TryEncode(args[0].String())
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{"primary": prim, "secondary": sec}
+}
+
+// parseOptions reads the options object of a call, undefined and null are the defaults
+func parseOptions(v js.Value) (pools.Options, error) {
+	var o pools.Options
+	switch v.Type() {
+	case js.TypeUndefined, js.TypeNull:
+		return o, nil
+	case js.TypeObject:
+	default:
+		return o, fmt.Errorf("options must be an object")
+	}
+
+	o.Vowels = v.Get("vowels").Truthy()
+	o.Exact = v.Get("exact").Truthy()
+	o.FullLength = v.Get("full_length").Truthy()
+	switch l := v.Get("max_length"); l.Type() {
+	case js.TypeUndefined, js.TypeNull:
+	case js.TypeNumber:
+		// checked before converting since any float can be passed
+		n := l.Float()
+		if n < 0 || n > pools.MaxLength || n != math.Trunc(n) {
+			return o, fmt.Errorf("max_length must be between 0 and %v", pools.MaxLength)
+		}
+		o.MaxLength = int(n)
+	default:
+		return o, fmt.Errorf("max_length must be a number")
+	}
+	switch in := v.Get("input"); in.Type() {
+	case js.TypeUndefined, js.TypeNull:
+	case js.TypeString:
+		var err error
+		if o.Input, err = metaphone3.ParseInputPolicy(in.String()); err != nil {
+			return o, fmt.Errorf("unknown input %q", in.String())
+		}
+	default:
+		return o, fmt.Errorf("input must be a string")
+	}
+	return o, nil
+}
@@ -0,0 +1,13 @@
+//go:build !js && !wasip1
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "metaphone3wasm must be built with GOOS=js GOARCH=wasm or GOOS=wasip1 GOARCH=wasm")
+	os.Exit(2)
+}
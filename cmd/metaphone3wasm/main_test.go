@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWebAssembly builds the js and WASI modules and checks them against the golden
+// files with node
+func TestWebAssembly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the WebAssembly builds in short mode")
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wasmExec := filepath.Join(strings.TrimSpace(string(goroot)), "lib", "wasm", "wasm_exec.js")
+	if _, err := os.Stat(wasmExec); err != nil {
+		t.Skip("wasm_exec.js not found")
+	}
+
+	dir := t.TempDir()
+	build := func(goos, out string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("go", append(append([]string{"build", "-o", out}, args...), ".")...)
+		cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH=wasm")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("building for %v: %v\n%s", goos, err, out)
+		}
+	}
+	jsWasm, wasiWasm := filepath.Join(dir, "js.wasm"), filepath.Join(dir, "wasi.wasm")
+	build("js", jsWasm)
+	build("wasip1", wasiWasm, "-buildmode=c-shared")
+
+	golden, err := filepath.Glob(filepath.Join("..", "..", "testdata", "*.test"))
+	if err != nil || len(golden) == 0 {
+		t.Fatalf("no golden files: %v", err)
+	}
+	out, err := exec.Command(node, append([]string{filepath.Join("testdata", "golden.js"), wasmExec, jsWasm, wasiWasm}, golden...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	t.Logf("%s", out)
+}
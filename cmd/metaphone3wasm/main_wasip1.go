@@ -0,0 +1,45 @@
+package main
+
+import (
+	"unsafe"
+
+	"github.com/dlclark/metaphone3/internal/pools"
+)
+
+// buf holds the input and result of metaphone3_encode
+var buf = make([]byte, 256)
+
+// main isn't run in a reactor, the host calls the exports after _initialize
+func main() {}
+
+//go:wasmexport metaphone3_buffer
+func metaphone3_buffer(size uint32) unsafe.Pointer {
+	if int(size) > cap(buf) {
+		buf = make([]byte, size)
+	}
+	buf = buf[:cap(buf)]
+	return unsafe.Pointer(&buf[0])
+}
+
+//go:wasmexport metaphone3_encode
+func metaphone3_encode(length uint32, flags int32, maxLength int32) int32 {
+	if int(length) > len(buf) || flags&^allFlags != 0 {
+		return -1
+	}
+	p, err := pool(pools.Options{
+		Vowels:     flags&flagEncodeVowels != 0,
+		Exact:      flags&flagEncodeExact != 0,
+		FullLength: flags&flagFullLength != 0,
+		MaxLength:  int(maxLength),
+	})
+	if err != nil {
+		return -1
+	}
+
+	prim, sec, err := p.TryEncode(string(buf[:length]))
+	if err != nil {
+		return -2
+	}
+	buf = append(append(append(buf[:0], prim...), '\t'), sec...)
+	return int32(len(buf))
+}
@@ -0,0 +1,118 @@
+// Checks the WebAssembly builds against the golden files, run by TestWebAssembly:
+//
+//	node testdata/golden.js wasm_exec.js js.wasm wasi.wasm golden.test...
+"use strict";
+
+const fs = require("fs");
+const { WASI } = require("wasi");
+
+const [wasmExec, jsWasm, wasiWasm, ...goldenFiles] = process.argv.slice(2);
+
+globalThis.require = require;
+globalThis.fs = fs;
+globalThis.path = require("path");
+globalThis.TextEncoder = require("util").TextEncoder;
+globalThis.TextDecoder = require("util").TextDecoder;
+globalThis.performance ??= require("perf_hooks").performance;
+globalThis.crypto ??= require("crypto");
+require(wasmExec);
+
+// the options of each pair of keys in a golden file line:
+// originalWord,main !v!e,alt !v!e,main ve,alt ve,main !ve,alt !ve,main v!e,alt v!e
+const combos = [
+	{ vowels: false, exact: false },
+	{ vowels: true, exact: true },
+	{ vowels: false, exact: true },
+	{ vowels: true, exact: false },
+];
+
+async function loadJS() {
+	const go = new Go();
+	const { instance } = await WebAssembly.instantiate(fs.readFileSync(jsWasm), go.importObject);
+	// main registers metaphone3 before it blocks
+	go.run(instance);
+	return (value, options) => globalThis.metaphone3.encode(value, options);
+}
+
+async function loadWASI() {
+	const wasi = new WASI({ version: "preview1" });
+	const { instance } = await WebAssembly.instantiate(fs.readFileSync(wasiWasm), wasi.getImportObject());
+	wasi.initialize(instance);
+	const { memory, metaphone3_buffer, metaphone3_encode } = instance.exports;
+	const encoder = new TextEncoder();
+	const decoder = new TextDecoder();
+
+	return (value, options = {}) => {
+		const flags = (options.vowels ? 1 : 0) | (options.exact ? 2 : 0) | (options.full_length ? 4 : 0);
+		const input = encoder.encode(value);
+		new Uint8Array(memory.buffer, metaphone3_buffer(input.length), input.length).set(input);
+		const n = metaphone3_encode(input.length, flags, options.max_length ?? 0);
+		if (n < 0) {
+			return { error: `metaphone3_encode returned ${n}` };
+		}
+		const [primary, secondary] = decoder.decode(new Uint8Array(memory.buffer, metaphone3_buffer(0), n)).split("\t");
+		return { primary, secondary };
+	};
+}
+
+let failures = 0;
+
+function check(name, got, want) {
+	if (got.error !== want.error || got.primary !== want.primary || got.secondary !== want.secondary) {
+		if (failures < 20) {
+			console.log(`FAIL ${name}: wanted ${JSON.stringify(want)}, got ${JSON.stringify(got)}`);
+		}
+		failures++;
+	}
+}
+
+function checkGolden(name, encode) {
+	let count = 0;
+	for (const file of goldenFiles) {
+		for (const line of fs.readFileSync(file, "utf8").split("\n")) {
+			if (line === "") {
+				continue;
+			}
+			const fields = line.split(",");
+			combos.forEach((options, i) => {
+				check(`${name} ${fields[0]} ${JSON.stringify(options)}`, encode(fields[0], options),
+					{ primary: fields[1 + 2 * i], secondary: fields[2 + 2 * i] });
+			});
+			count++;
+		}
+	}
+	console.log(`${name}: ${count} words`);
+}
+
+async function main() {
+	const js = await loadJS();
+	const wasi = await loadWASI();
+
+	for (const [name, encode] of [["js", js], ["wasi", wasi]]) {
+		checkGolden(name, encode);
+		check(`${name} full length`, encode("Alexandropavlovskov", { full_length: true }), { primary: "ALKSNTRPFLFSKF", secondary: "" });
+		check(`${name} max length`, encode("Villafranca", { vowels: true, exact: true, max_length: 4 }), { primary: "VALA", secondary: "VAFR" });
+		check(`${name} no options`, encode("Smith"), { primary: "SM0", secondary: "XMT" });
+	}
+	check("js input", js("Smith3", { input: "reject" }), { error: 'metaphone3: input has a character that isn\'t a letter: "Smith3"' });
+	check("js bad input", js("Smith", { input: "bogus" }), { error: 'unknown input "bogus"' });
+	check("js bad max_length", js("Smith", { max_length: -1 }), { error: "max_length must be between 0 and 64" });
+	check("js long max_length", js("Smith", { max_length: 65 }), { error: "max_length must be between 0 and 64" });
+	check("js huge max_length", js("Smith", { max_length: 1e20 }), { error: "max_length must be between 0 and 64" });
+	check("js fractional max_length", js("Smith", { max_length: 2.5 }), { error: "max_length must be between 0 and 64" });
+	check("js bad value", js(42), { error: "value must be a string" });
+	check("wasi bad flags", wasi("Smith", { max_length: -1 }), { error: "metaphone3_encode returned -1" });
+	check("wasi long max_length", wasi("Smith", { max_length: 65 }), { error: "metaphone3_encode returned -1" });
+
+	if (failures > 0) {
+		console.log(`${failures} failures`);
+		process.exit(1);
+	}
+	console.log("ok");
+	process.exit(0);
+}
+
+main().catch((err) => {
+	console.error(err);
+	process.exit(1);
+});
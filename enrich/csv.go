@@ -0,0 +1,75 @@
+package enrich
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// CSV reads CSV with a header row from r and writes it to w with the key columns added
+// at the end of each row.  Every row must have the same number of fields as the header.
+func CSV(r io.Reader, w io.Writer, o Options) error {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return fmt.Errorf("enrich: no header row")
+	} else if err != nil {
+		return err
+	}
+
+	cols := make([]int, len(o.Columns))
+	for i, name := range o.Columns {
+		cols[i] = -1
+		for j, h := range header {
+			if h == name {
+				cols[i] = j
+				break
+			}
+		}
+		if cols[i] < 0 {
+			return fmt.Errorf("enrich: column %q not found", name)
+		}
+	}
+	added, err := o.newColumns(header)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append(header, added...)); err != nil {
+		return err
+	}
+
+	size := o.batchSize()
+	next := func() ([][]string, []string, error) {
+		rows := make([][]string, 0, size)
+		values := make([]string, 0, size*len(cols))
+		for len(rows) < size {
+			row, err := cr.Read()
+			if err != nil {
+				return rows, values, err
+			}
+			rows = append(rows, row)
+			for _, c := range cols {
+				values = append(values, row[c])
+			}
+		}
+		return rows, values, nil
+	}
+	emit := func(rows [][]string, keys []string) error {
+		n := len(added)
+		for i, row := range rows {
+			if err := cw.Write(append(row, keys[i*n:(i+1)*n]...)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	if err := pipeline(&o, next, emit); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,152 @@
+// Package enrich adds Metaphone 3 key columns to tabular files, such as
+// surname_mp3_primary and surname_mp3_secondary for a surname column.  Files are
+// streamed in batches that are encoded in parallel, and the rows are written in
+// their original order with the original columns unchanged.
+package enrich
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/dlclark/metaphone3"
+)
+
+// DefaultBatchSize is the number of rows encoded at a time if Options.BatchSize isn't set.
+const DefaultBatchSize = 1024
+
+// Options configures an enrichment.
+type Options struct {
+	// Columns are the names of the columns to encode, each gets a primary and a secondary
+	// column added after the existing columns
+	Columns []string
+	// Encoder has the options to encode with, it's only used to copy the options.  If nil
+	// the default options are used.
+	Encoder *metaphone3.Encoder
+	// Workers is the number of goroutines encoding, 0 for GOMAXPROCS
+	Workers int
+	// BatchSize is the number of rows in each batch, 0 for DefaultBatchSize
+	BatchSize int
+}
+
+// ColumnNames returns the names of the key columns added for a column.
+func ColumnNames(column string) (primary, secondary string) {
+	return column + "_mp3_primary", column + "_mp3_secondary"
+}
+
+// newColumns returns the names of the key columns added for all the columns, after
+// checking they don't clash with the existing columns
+func (o *Options) newColumns(existing []string) ([]string, error) {
+	if len(o.Columns) == 0 {
+		return nil, errors.New("enrich: no columns to encode")
+	}
+	have := make(map[string]bool, len(existing))
+	for _, c := range existing {
+		have[c] = true
+	}
+
+	var out []string
+	for _, c := range o.Columns {
+		prim, sec := ColumnNames(c)
+		for _, n := range []string{prim, sec} {
+			if have[n] {
+				return nil, fmt.Errorf("enrich: column %q already exists", n)
+			}
+			have[n] = true
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+func (o *Options) batchSize() int {
+	if o.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return o.BatchSize
+}
+
+// batch is a batch of rows of type T on its way through the pipeline
+type batch[T any] struct {
+	rows []T
+	// values to encode, len(Columns) for each row
+	values []string
+	// keys of the values, primary then secondary for each value
+	keys []string
+	done chan struct{}
+}
+
+// pipeline calls next to read batches of rows and the values to encode until it
+// returns an error, encodes them on the workers, and calls emit with the keys of
+// each batch in the order they were read.  io.EOF from next isn't an error.
+func pipeline[T any](o *Options, next func() ([]T, []string, error), emit func(rows []T, keys []string) error) error {
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	pool := metaphone3.NewPool(o.Encoder)
+
+	jobs := make(chan *batch[T])
+	for i := 0; i < workers; i++ {
+		go func() {
+			e := pool.Get()
+			defer pool.Put(e)
+			for b := range jobs {
+				b.keys = make([]string, 2*len(b.values))
+				for i, v := range b.values {
+					b.keys[2*i], b.keys[2*i+1] = e.Encode(v)
+				}
+				close(b.done)
+			}
+		}()
+	}
+
+	// batches in order, the buffer lets the reader and workers run ahead of emit
+	order := make(chan *batch[T], workers)
+	stop := make(chan struct{})
+	var readErr error
+	go func() {
+		defer close(order)
+		defer close(jobs)
+		for {
+			// don't read any further once emit has failed
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			rows, values, err := next()
+			if len(rows) > 0 {
+				b := &batch[T]{rows: rows, values: values, done: make(chan struct{})}
+				select {
+				case order <- b:
+				case <-stop:
+					return
+				}
+				jobs <- b
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	var err error
+	for b := range order {
+		<-b.done
+		if err != nil {
+			continue
+		}
+		if err = emit(b.rows, b.keys); err != nil {
+			close(stop)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return readErr
+}
@@ -0,0 +1,239 @@
+package enrich
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestCSV(t *testing.T) {
+	in := "id,surname,given\n1,Smith,John\n2,Schmidt,\n3,\"Smyth, Jr\",Ian\n"
+	var out bytes.Buffer
+	if err := CSV(strings.NewReader(in), &out, Options{Columns: []string{"surname", "given"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,surname,given,surname_mp3_primary,surname_mp3_secondary,given_mp3_primary,given_mp3_secondary\n" +
+		"1,Smith,John,SM0,XMT,JN,AN\n" +
+		"2,Schmidt,,XMT,,,\n" +
+		"3,\"Smyth, Jr\",Ian,SM0JR,XMTJR,AN,\n"
+	if out.String() != want {
+		t.Errorf("wanted\n%v\ngot\n%v", want, out.String())
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in, column, err string
+	}{
+		{"", "surname", "enrich: no header row"},
+		{"id,name\n", "surname", `enrich: column "surname" not found`},
+		{"surname,surname_mp3_primary\n", "surname", `enrich: column "surname_mp3_primary" already exists`},
+		{"id,surname\n1,Smith\n2\n", "surname", "record on line 3: wrong number of fields"},
+	} {
+		err := CSV(strings.NewReader(tc.in), io.Discard, Options{Columns: []string{tc.column}})
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("%q: wanted error %q, got %v", tc.in, tc.err, err)
+		}
+	}
+	if err := CSV(strings.NewReader("id\n"), io.Discard, Options{}); err == nil {
+		t.Error("wanted an error with no columns")
+	}
+}
+
+// names returns the surnames test corpus
+func names(t testing.TB) []string {
+	f, err := os.Open("../testdata/surnames-us.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		out = append(out, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestCSVOrder(t *testing.T) {
+	ns := names(t)[:20000]
+	var in, want bytes.Buffer
+	in.WriteString("n,surname\n")
+	want.WriteString("n,surname,surname_mp3_primary,surname_mp3_secondary\n")
+	e := &metaphone3.Encoder{EncodeVowels: true}
+	for i, n := range ns {
+		fmt.Fprintf(&in, "%d,%s\n", i, n)
+		prim, sec := e.Encode(n)
+		fmt.Fprintf(&want, "%d,%s,%s,%s\n", i, n, prim, sec)
+	}
+
+	var out bytes.Buffer
+	o := Options{Columns: []string{"surname"}, Encoder: e, Workers: 8, BatchSize: 7}
+	if err := CSV(&in, &out, o); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want.String() {
+		t.Error("output doesn't match encoding each row in order")
+	}
+}
+
+type failWriter struct {
+	n int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.n -= len(p); w.n < 0 {
+		return 0, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func TestCSVWriteError(t *testing.T) {
+	var in bytes.Buffer
+	in.WriteString("surname\n")
+	for _, n := range names(t)[:20000] {
+		in.WriteString(n + "\n")
+	}
+	err := CSV(&in, &failWriter{n: 10000}, Options{Columns: []string{"surname"}, Workers: 4, BatchSize: 10})
+	if err == nil || err.Error() != "disk full" {
+		t.Errorf("wanted the write error, got %v", err)
+	}
+}
+
+func TestPipelineStopsReading(t *testing.T) {
+	// an endless input, reading has to stop because emit failed
+	reads := 0
+	next := func() ([]int, []string, error) {
+		reads++
+		return []int{reads}, []string{"Smith"}, nil
+	}
+	emit := func([]int, []string) error {
+		return errors.New("disk full")
+	}
+
+	err := pipeline(&Options{Workers: 2}, next, emit)
+	if err == nil || err.Error() != "disk full" {
+		t.Fatalf("wanted the emit error, got %v", err)
+	}
+	// the first batch, the batches buffered for the workers and one read in progress
+	if reads > 5 {
+		t.Fatalf("wanted reading to stop after the emit error, read %v batches", reads)
+	}
+}
+
+type person struct {
+	ID      int64    `parquet:"id"`
+	Surname string   `parquet:"surname"`
+	Given   *string  `parquet:"given,optional"`
+	Tags    []string `parquet:"tags,list"`
+	Age     int32    `parquet:"age"`
+}
+
+func TestParquet(t *testing.T) {
+	ian := "Ian"
+	people := []person{
+		{ID: 1, Surname: "Smith", Given: &ian, Tags: []string{"a", "b"}, Age: 40},
+		{ID: 2, Surname: "Schmidt", Age: 50},
+	}
+	for i, n := range names(t)[:5000] {
+		people = append(people, person{ID: int64(i + 3), Surname: n, Given: &n, Tags: []string{n}, Age: int32(i)})
+	}
+
+	var in bytes.Buffer
+	pw := parquet.NewGenericWriter[person](&in, parquet.KeyValueMetadata("source", "test"))
+	if _, err := pw.Write(people); err != nil {
+		t.Fatal(err)
+	}
+	if err := pw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	o := Options{Columns: []string{"surname", "given"}, Workers: 4, BatchSize: 100}
+	if err := Parquet(bytes.NewReader(in.Bytes()), int64(in.Len()), &out, o); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields []string
+	for _, field := range f.Schema().Fields() {
+		fields = append(fields, field.Name())
+	}
+	wantFields := []string{"id", "surname", "given", "tags", "age",
+		"surname_mp3_primary", "surname_mp3_secondary", "given_mp3_primary", "given_mp3_secondary"}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("wanted fields %v, got %v", wantFields, fields)
+	}
+	if v, _ := f.Lookup("source"); v != "test" {
+		t.Errorf("wanted the metadata copied, got %q", v)
+	}
+
+	type enriched struct {
+		person
+		SurnamePrimary   string `parquet:"surname_mp3_primary"`
+		SurnameSecondary string `parquet:"surname_mp3_secondary"`
+		GivenPrimary     string `parquet:"given_mp3_primary"`
+		GivenSecondary   string `parquet:"given_mp3_secondary"`
+	}
+	got := make([]enriched, len(people)+1)
+	n, err := parquet.NewGenericReader[enriched](f).Read(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != len(people) {
+		t.Fatalf("wanted %v rows, got %v", len(people), n)
+	}
+
+	e := &metaphone3.Encoder{}
+	for i, p := range people {
+		g := got[i]
+		given := ""
+		if p.Given != nil {
+			given = *p.Given
+		}
+		sp, ss := e.Encode(p.Surname)
+		gp, gs := e.Encode(given)
+		if !reflect.DeepEqual(g.person, p) && !(p.Tags == nil && len(g.Tags) == 0) {
+			t.Errorf("row %v: wanted %+v, got %+v", i, p, g.person)
+		}
+		if g.SurnamePrimary != sp || g.SurnameSecondary != ss || g.GivenPrimary != gp || g.GivenSecondary != gs {
+			t.Errorf("row %v %v %v: wanted %v %v %v %v, got %v %v %v %v", i, p.Surname, given,
+				sp, ss, gp, gs, g.SurnamePrimary, g.SurnameSecondary, g.GivenPrimary, g.GivenSecondary)
+		}
+	}
+}
+
+func TestParquetErrors(t *testing.T) {
+	var in bytes.Buffer
+	pw := parquet.NewGenericWriter[person](&in)
+	pw.Write([]person{{ID: 1, Surname: "Smith"}})
+	pw.Close()
+
+	for _, tc := range []struct {
+		column, err string
+	}{
+		{"name", `enrich: column "name" isn't a top level string column`},
+		{"age", `enrich: column "age" isn't a top level string column`},
+		{"tags", `enrich: column "tags" isn't a top level string column`},
+	} {
+		err := Parquet(bytes.NewReader(in.Bytes()), int64(in.Len()), io.Discard, Options{Columns: []string{tc.column}})
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("%v: wanted error %q, got %v", tc.column, tc.err, err)
+		}
+	}
+}
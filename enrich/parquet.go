@@ -0,0 +1,129 @@
+package enrich
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Parquet reads a Parquet file from r and writes it to w with the key columns added
+// after the existing fields.  The encoded columns must be top level string (or byte
+// array) columns, null values are encoded as blank.  The key columns are required
+// strings, and the key/value metadata of the file is copied.
+func Parquet(r io.ReaderAt, size int64, w io.Writer, o Options) error {
+	f, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return err
+	}
+	schema := f.Schema()
+
+	var existing []string
+	for _, field := range schema.Fields() {
+		existing = append(existing, field.Name())
+	}
+	added, err := o.newColumns(existing)
+	if err != nil {
+		return err
+	}
+
+	cols := make([]int, len(o.Columns))
+	for i, name := range o.Columns {
+		leaf, ok := schema.Lookup(name)
+		if !ok || leaf.MaxRepetitionLevel > 0 || leaf.Node.Type().Kind() != parquet.ByteArray {
+			return fmt.Errorf("enrich: column %q isn't a top level string column", name)
+		}
+		cols[i] = leaf.ColumnIndex
+	}
+
+	// the key columns are appended so the existing columns keep their indexes
+	root := &appendedNode{Node: schema, fields: schema.Fields()}
+	for _, name := range added {
+		root.fields = append(root.fields, parquet.Group{name: parquet.String()}.Fields()[0])
+	}
+	firstKey := len(schema.Columns())
+
+	var opts []parquet.WriterOption
+	for _, kv := range f.Metadata().KeyValueMetadata {
+		opts = append(opts, parquet.KeyValueMetadata(kv.Key, kv.Value))
+	}
+	pw := parquet.NewWriter(w, append(opts, parquet.NewSchema(schema.Name(), root))...)
+
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+	batchSize := o.batchSize()
+	next := func() ([]parquet.Row, []string, error) {
+		rows := make([]parquet.Row, batchSize)
+		n, err := reader.ReadRows(rows)
+		rows = rows[:n]
+		values := make([]string, 0, n*len(cols))
+		for i, row := range rows {
+			// the values can share buffers with the reader, and the batch outlives the next read
+			rows[i] = row.Clone()
+			for _, c := range cols {
+				values = append(values, columnValue(row, c))
+			}
+		}
+		if n == 0 && err == nil {
+			err = io.EOF
+		}
+		return rows, values, err
+	}
+	emit := func(rows []parquet.Row, keys []string) error {
+		n := len(added)
+		for i := range rows {
+			for j, k := range keys[i*n : (i+1)*n] {
+				rows[i] = append(rows[i], parquet.ByteArrayValue([]byte(k)).Level(0, 0, firstKey+j))
+			}
+		}
+		_, err := pw.WriteRows(rows)
+		return err
+	}
+
+	if err := pipeline(&o, next, emit); err != nil {
+		return err
+	}
+	return pw.Close()
+}
+
+// columnValue returns the value of a top level column in a row, blank if it's null
+func columnValue(row parquet.Row, column int) string {
+	for _, v := range row {
+		if v.Column() == column {
+			if v.IsNull() {
+				return ""
+			}
+			return string(v.ByteArray())
+		}
+	}
+	return ""
+}
+
+// appendedNode is a group with fields in a set order, unlike parquet.Group which sorts
+// its fields by name
+type appendedNode struct {
+	parquet.Node
+	fields []parquet.Field
+}
+
+func (n *appendedNode) Fields() []parquet.Field {
+	return n.fields
+}
+
+func (n *appendedNode) String() string {
+	return n.group().String()
+}
+
+func (n *appendedNode) GoType() reflect.Type {
+	return n.group().GoType()
+}
+
+// group returns the fields as a parquet.Group, in name order
+func (n *appendedNode) group() parquet.Group {
+	g := make(parquet.Group, len(n.fields))
+	for _, f := range n.fields {
+		g[f.Name()] = f
+	}
+	return g
+}
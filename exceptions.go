@@ -0,0 +1,225 @@
+package metaphone3
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Exception is a forced encoding for a word, or for the start of words beginning with a prefix.
+type Exception struct {
+	// Primary and Secondary are the keys to use, if Secondary is blank then there's
+	// no alternate.  For prefixes they're only the encoding of the prefix, the rest
+	// of the word is encoded by the rules as usual.
+	Primary, Secondary string
+
+	// Supplement keeps the output of the rules and only adds this encoding as an
+	// alternate.  It's only supported for whole words.  Encode has room for one
+	// alternate, so Primary becomes the secondary only when the rules didn't come up
+	// with one within the max length, and Secondary isn't used.  EncodeAll always
+	// includes both, ranked along with the rules' alternates.
+	Supplement bool
+}
+
+// Exceptions is a dictionary of forced encodings that is checked before the rules are run.
+// Entries either match a whole word exactly or match words that start with a prefix, when both
+// match the whole word wins and the longest prefix wins over shorter ones.  Matching ignores case.
+//
+// An Exceptions can be shared by many Encoders across goroutines once it's done being built.
+type Exceptions struct {
+	words    map[string]Exception
+	prefixes map[string]Exception
+	// distinct prefix lengths (in runes), longest first
+	prefixLens []int
+}
+
+// NewExceptions returns an empty exceptions dictionary.
+func NewExceptions() *Exceptions {
+	return &Exceptions{
+		words:    make(map[string]Exception),
+		prefixes: make(map[string]Exception),
+	}
+}
+
+// AddWord adds a forced encoding for a whole word, replacing any previous entry for the word.
+func (x *Exceptions) AddWord(word string, ex Exception) {
+	x.words[normalizeException(word)] = normalizeKeys(ex)
+}
+
+// AddPrefix adds a forced encoding for words starting with prefix, replacing any previous
+// entry for the prefix.  It returns an error if the exception is a supplement since prefix
+// entries can only override.
+func (x *Exceptions) AddPrefix(prefix string, ex Exception) error {
+	if ex.Supplement {
+		return fmt.Errorf("prefix %q: prefix exceptions can't be supplements", prefix)
+	}
+	prefix = normalizeException(prefix)
+	if prefix == "" {
+		return fmt.Errorf("prefix exceptions can't be blank")
+	}
+	x.prefixes[prefix] = normalizeKeys(ex)
+
+	l := len([]rune(prefix))
+	for i, have := range x.prefixLens {
+		if have == l {
+			return nil
+		} else if have < l {
+			x.prefixLens = append(x.prefixLens[:i], append([]int{l}, x.prefixLens[i:]...)...)
+			return nil
+		}
+	}
+	x.prefixLens = append(x.prefixLens, l)
+	return nil
+}
+
+// Len returns the number of word and prefix entries.
+func (x *Exceptions) Len() int {
+	return len(x.words) + len(x.prefixes)
+}
+
+// lookup finds the entry for the given upper-cased input and returns it along with
+// the number of runes of the input it covers
+func (x *Exceptions) lookup(in []rune) (Exception, int, bool) {
+	if x == nil {
+		return Exception{}, 0, false
+	}
+	if ex, ok := x.words[string(in)]; ok {
+		return ex, len(in), true
+	}
+	for _, l := range x.prefixLens {
+		if l > len(in) {
+			continue
+		}
+		if ex, ok := x.prefixes[string(in[:l])]; ok {
+			return ex, l, true
+		}
+	}
+	return Exception{}, 0, false
+}
+
+// LoadExceptions reads an exceptions dictionary in CSV form, one entry per line:
+//
+//	word,primary[,secondary[,supplement]]
+//
+// A word ending in '*' is a prefix entry.  The last column can be "supplement"
+// to keep the rule output and add the entry as an alternate.  Blank lines and lines
+// starting with '#' are ignored.  For example:
+//
+//	# customer names
+//	Siobhan,XFN
+//	Nguyen,NKN,NN,supplement
+//	Mc*,MK
+func LoadExceptions(r io.Reader) (*Exceptions, error) {
+	x := NewExceptions()
+
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		row, _ := reader.FieldPos(0)
+
+		if len(line) < 2 || len(line) > 4 {
+			return nil, fmt.Errorf("line %v: wanted 2 to 4 columns, got %v", row, len(line))
+		}
+
+		word := strings.TrimSpace(line[0])
+		ex := Exception{Primary: strings.TrimSpace(line[1])}
+		if len(line) > 2 {
+			ex.Secondary = strings.TrimSpace(line[2])
+		}
+		if len(line) > 3 {
+			switch flag := strings.TrimSpace(line[3]); flag {
+			case "supplement":
+				ex.Supplement = true
+			case "", "override":
+			default:
+				return nil, fmt.Errorf("line %v: unknown flag %q", row, flag)
+			}
+		}
+
+		if word == "" || ex.Primary == "" {
+			return nil, fmt.Errorf("line %v: word and primary can't be blank", row)
+		}
+
+		if strings.HasSuffix(word, "*") {
+			if err := x.AddPrefix(strings.TrimSuffix(word, "*"), ex); err != nil {
+				return nil, fmt.Errorf("line %v: %v", row, err)
+			}
+		} else {
+			x.AddWord(word, ex)
+		}
+	}
+
+	return x, nil
+}
+
+// LoadExceptionsFile reads an exceptions dictionary from a CSV file, see LoadExceptions for the format.
+func LoadExceptionsFile(filename string) (*Exceptions, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadExceptions(f)
+}
+
+func normalizeException(s string) string {
+	return strings.Map(unicode.ToUpper, s)
+}
+
+func normalizeKeys(ex Exception) Exception {
+	ex.Primary = strings.ToUpper(ex.Primary)
+	ex.Secondary = strings.ToUpper(ex.Secondary)
+	return ex
+}
+
+// applyException checks the exceptions for the current input, an overriding entry
+// is added to the outputs and the index to continue encoding from is returned
+func (e *Encoder) applyException() int {
+	e.supplement = nil
+
+	ex, n, ok := e.Exceptions.lookup(e.in)
+	if !ok {
+		return 0
+	}
+
+	if ex.Supplement {
+		e.supplement = &ex
+		return 0
+	}
+
+	sec := ex.Secondary
+	if sec == "" {
+		sec = ex.Primary
+	}
+	e.idx = n - 1
+	e.metaphAddStr(ex.Primary, sec)
+	return n
+}
+
+// applySupplement adds a supplemental exception as the secondary when the rules
+// didn't come up with one, a secondary from the rules wins.  The buffers must already
+// be trimmed to maxLen.
+func (e *Encoder) applySupplement() {
+	if e.supplement == nil || !areEqual(e.primBuf, e.secondBuf) {
+		return
+	}
+	sup := []rune(e.supplement.Primary)
+	if len(sup) > e.maxLen {
+		sup = sup[:e.maxLen]
+		e.truncated = true
+	}
+	e.secondBuf = append(e.secondBuf[:0], sup...)
+}
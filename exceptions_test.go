@@ -0,0 +1,114 @@
+package metaphone3
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testExceptions = `
+# forced encodings
+Siobhan,XFN
+Nguyen, NKN, NN, supplement
+mc*,MK
+macd*,MKT,MT
+`
+
+func TestExceptions_Encode(t *testing.T) {
+	x, err := LoadExceptions(strings.NewReader(testExceptions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 4, x.Len(); want != got {
+		t.Fatalf("wanted %v entries, got %v", want, got)
+	}
+
+	vals := []struct{ in, prim, sec string }{
+		// whole word override
+		{"siobhan", "XFN", ""},
+		// supplement becomes the secondary
+		{"Nguyen", "NN", "NKN"},
+		// prefixes, the rest of the word uses the rules
+		{"McGregor", "MKRKR", ""},
+		{"MacDonald", "MKTNLT", "MTNLT"},
+		{"Macintosh", "MKNTX", ""},
+		// no match
+		{"Smith", "SM0", "XMT"},
+	}
+	e := &Encoder{Exceptions: x}
+
+	for _, v := range vals {
+		prim, sec := e.Encode(v.in)
+		if prim != v.prim {
+			t.Errorf("Invalid primary output on '%v', wanted %v, got %v", v.in, v.prim, prim)
+		}
+		if sec != v.sec {
+			t.Errorf("Invalid secondary output on '%v', wanted %v, got %v", v.in, v.sec, sec)
+		}
+	}
+}
+
+func TestExceptions_EncodeAll(t *testing.T) {
+	x := NewExceptions()
+	x.AddWord("nguyen", Exception{Primary: "NKN", Secondary: "NWN", Supplement: true})
+	if err := x.AddPrefix("macd", Exception{Primary: "MKT", Secondary: "MT"}); err != nil {
+		t.Fatal(err)
+	}
+	e := &Encoder{Exceptions: x}
+
+	if want, got := []string{"NN", "NKN", "NWN"}, e.EncodeAll("Nguyen"); !reflect.DeepEqual(want, got) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+	if want, got := []string{"MKTNLT", "MTNLT"}, e.EncodeAll("MacDonald"); !reflect.DeepEqual(want, got) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestExceptions_SupplementPrecedence(t *testing.T) {
+	x := NewExceptions()
+	x.AddWord("smith", Exception{Primary: "SMT", Secondary: "SNT", Supplement: true})
+	e := &Encoder{Exceptions: x}
+
+	// the rules already have a secondary so Encode keeps it
+	prim, sec := e.Encode("Smith")
+	if prim != "SM0" || sec != "XMT" {
+		t.Errorf("wanted SM0 XMT, got %v %v", prim, sec)
+	}
+	// EncodeAll has room for both of the supplement's keys
+	if want, got := []string{"SM0", "XMT", "SMT", "XM0", "SNT"}, e.EncodeAll("Smith"); !reflect.DeepEqual(want, got) {
+		t.Errorf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestExceptions_SupplementMaxLength(t *testing.T) {
+	x := NewExceptions()
+	for _, w := range []string{"dixion", "killelea", "ciallella", "brzezinski"} {
+		x.AddWord(w, Exception{Primary: "QQQ", Supplement: true})
+	}
+	e := &Encoder{Exceptions: x, MaxLength: 2}
+
+	// the rules' keys only differ past the max length, so the supplement is the secondary
+	for _, v := range []struct{ in, prim string }{
+		{"Dixion", "TK"},
+		{"Killelea", "KL"},
+		{"Ciallella", "SL"},
+		{"Brzezinski", "PR"},
+	} {
+		if prim, sec := e.Encode(v.in); prim != v.prim || sec != "QQ" {
+			t.Errorf("%v: wanted %v QQ, got %v %v", v.in, v.prim, prim, sec)
+		}
+	}
+}
+
+func TestExceptions_LoadErrors(t *testing.T) {
+	for _, in := range []string{
+		"smith",
+		"smith,SM0,XMT,maybe",
+		"mc*,MK,,supplement",
+		",SM0",
+	} {
+		if _, err := LoadExceptions(strings.NewReader(in)); err == nil {
+			t.Errorf("wanted an error loading %q", in)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package grpcserver implements the Metaphone3 gRPC service defined in
+// proto/metaphone3/v1/metaphone3.proto.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/dlclark/metaphone3/index"
+	"github.com/dlclark/metaphone3/internal/pools"
+	pb "github.com/dlclark/metaphone3/metaphone3pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxKeyLength is the largest max_length a request can ask for, full_length gives longer keys
+const MaxKeyLength = pools.MaxLength
+
+// Server is the Metaphone3 service.  Encoders are pooled for each set of options.
+type Server struct {
+	pb.UnimplementedMetaphone3Server
+
+	// MaxCandidates limits the candidates of a Match request, 0 for no limit
+	MaxCandidates int
+
+	// Stats, if set before serving, counts the values encoded
+	Stats *metaphone3.Stats
+
+	pools pools.Cache
+}
+
+// New returns a Server with no limit on the number of candidates.
+func New() *Server {
+	return &Server{}
+}
+
+// Register registers a new Server with a gRPC server.
+func Register(s *grpc.Server) *Server {
+	srv := New()
+	pb.RegisterMetaphone3Server(s, srv)
+	return srv
+}
+
+// pool returns the encoders for the options after validating them
+func (s *Server) pool(o *pb.Options) (*metaphone3.Pool, error) {
+	input := metaphone3.InputSkip
+	if o.GetInput() != "" {
+		var err error
+		if input, err = metaphone3.ParseInputPolicy(o.GetInput()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown input %q", o.GetInput())
+		}
+	}
+
+	p, err := s.pools.Get(pools.Options{
+		Vowels:     o.GetEncodeVowels(),
+		Exact:      o.GetEncodeExact(),
+		MaxLength:  int(o.GetMaxLength()),
+		FullLength: o.GetFullLength(),
+		Input:      input,
+		Stats:      s.Stats,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "max_length must be between 0 and %v", MaxKeyLength)
+	}
+	return p, nil
+}
+
+func encode(p *metaphone3.Pool, value string) (*pb.Key, error) {
+	prim, sec, err := p.TryEncode(value)
+	if errors.Is(err, metaphone3.ErrNonLetter) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Key{Primary: prim, Secondary: sec}, nil
+}
+
+// Encode returns the keys for one value.
+func (s *Server) Encode(ctx context.Context, req *pb.EncodeRequest) (*pb.EncodeResponse, error) {
+	p, err := s.pool(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	key, err := encode(p, req.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EncodeResponse{Value: req.GetValue(), Key: key}, nil
+}
+
+// EncodeStream encodes each request of the stream and sends the responses in order.
+// Invalid options end the stream with an InvalidArgument error.
+func (s *Server) EncodeStream(stream pb.Metaphone3_EncodeStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		resp, err := s.Encode(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Match returns the candidates that sound like the value.
+func (s *Server) Match(ctx context.Context, req *pb.MatchRequest) (*pb.MatchResponse, error) {
+	if s.MaxCandidates > 0 && len(req.GetCandidates()) > s.MaxCandidates {
+		return nil, status.Errorf(codes.InvalidArgument, "more than %v candidates", s.MaxCandidates)
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit can't be negative")
+	}
+	p, err := s.pool(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	key, err := encode(p, req.GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	e := p.Get()
+	defer p.Put(e)
+	ix := index.New(e)
+	for _, c := range req.GetCandidates() {
+		ix.Add(c, "")
+	}
+
+	ms := ix.Search(req.GetValue())
+	if limit := int(req.GetLimit()); limit > 0 && len(ms) > limit {
+		ms = ms[:limit]
+	}
+
+	resp := &pb.MatchResponse{Key: key, Matches: make([]*pb.Match, len(ms))}
+	for i, m := range ms {
+		t := pb.MatchType_MATCH_TYPE_PRIMARY
+		if m.Type == index.SecondaryMatch {
+			t = pb.MatchType_MATCH_TYPE_SECONDARY
+		}
+		resp.Matches[i] = &pb.Match{
+			Value: m.Entry.Value,
+			Type:  t,
+			Count: int32(m.Entry.Count),
+			Key:   &pb.Key{Primary: m.Entry.Primary, Secondary: m.Entry.Secondary},
+		}
+	}
+	return resp, nil
+}
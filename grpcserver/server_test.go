@@ -0,0 +1,191 @@
+package grpcserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/dlclark/metaphone3"
+	pb "github.com/dlclark/metaphone3/metaphone3pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient starts an in-process server and returns a client connected to it
+func newTestClient(t *testing.T) pb.Metaphone3Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	srv := Register(s)
+	srv.MaxCandidates = 3
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewMetaphone3Client(conn)
+}
+
+func keyString(k *pb.Key) string {
+	return k.GetPrimary() + "/" + k.GetSecondary()
+}
+
+func TestEncode(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	resp, err := c.Encode(ctx, &pb.EncodeRequest{Value: "Smith"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "SM0/XMT", keyString(resp.GetKey()); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	resp, err = c.Encode(ctx, &pb.EncodeRequest{Value: "Villafranca", Options: &pb.Options{EncodeVowels: true, MaxLength: 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "FALA/FAFR", keyString(resp.GetKey()); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	resp, err = c.Encode(ctx, &pb.EncodeRequest{Value: "Alexandropavlovskov", Options: &pb.Options{FullLength: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "ALKSNTRPFLFSKF/", keyString(resp.GetKey()); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	resp, err = c.Encode(ctx, &pb.EncodeRequest{Value: "Smith-Jones", Options: &pb.Options{Input: "separate"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "SM0JNS/XMTANS", keyString(resp.GetKey()); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestEncode_InvalidOptions(t *testing.T) {
+	c := newTestClient(t)
+	for _, n := range []int32{-1, MaxKeyLength + 1} {
+		_, err := c.Encode(context.Background(), &pb.EncodeRequest{Value: "Smith", Options: &pb.Options{MaxLength: n}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("max_length %v: wanted InvalidArgument, got %v", n, err)
+		}
+	}
+
+	_, err := c.Encode(context.Background(), &pb.EncodeRequest{Value: "Smith", Options: &pb.Options{Input: "nope"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown input: wanted InvalidArgument, got %v", err)
+	}
+	_, err = c.Encode(context.Background(), &pb.EncodeRequest{Value: "Smith3", Options: &pb.Options{Input: "reject"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("rejected input: wanted InvalidArgument, got %v", err)
+	}
+}
+
+func TestEncodeStream(t *testing.T) {
+	c := newTestClient(t)
+	stream, err := c.EncodeStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []string{"Smith", "Schmidt", "Jones", ""}
+	go func() {
+		for _, v := range values {
+			stream.Send(&pb.EncodeRequest{Value: v, Options: &pb.Options{EncodeExact: true}})
+		}
+		stream.CloseSend()
+	}()
+
+	want := []string{"SM0/XMT", "XMT/", "JNS/ANS", "/"}
+	for i := 0; ; i++ {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			if i != len(want) {
+				t.Fatalf("wanted %v responses, got %v", len(want), i)
+			}
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetValue() != values[i] || keyString(resp.GetKey()) != want[i] {
+			t.Fatalf("wanted %v %v, got %v %v", values[i], want[i], resp.GetValue(), keyString(resp.GetKey()))
+		}
+	}
+}
+
+func TestEncodeStream_InvalidOptions(t *testing.T) {
+	c := newTestClient(t)
+	stream, err := c.EncodeStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&pb.EncodeRequest{Value: "Smith", Options: &pb.Options{MaxLength: -2}})
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("wanted InvalidArgument, got %v", err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	resp, err := c.Match(ctx, &pb.MatchRequest{Value: "Smythe", Candidates: []string{"Schmidt", "Smith", "Jones"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "SM0/XMT", keyString(resp.GetKey()); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+	if len(resp.GetMatches()) != 2 {
+		t.Fatalf("wanted 2 matches, got %v", resp.GetMatches())
+	}
+	if m := resp.GetMatches()[0]; m.GetValue() != "Smith" || m.GetType() != pb.MatchType_MATCH_TYPE_PRIMARY {
+		t.Fatalf("wanted a primary match on Smith, got %v", m)
+	}
+	if m := resp.GetMatches()[1]; m.GetValue() != "Schmidt" || m.GetType() != pb.MatchType_MATCH_TYPE_SECONDARY {
+		t.Fatalf("wanted a secondary match on Schmidt, got %v", m)
+	}
+
+	resp, err = c.Match(ctx, &pb.MatchRequest{Value: "Smythe", Candidates: []string{"Schmidt", "Smith"}, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetMatches()) != 1 {
+		t.Fatalf("wanted 1 match, got %v", resp.GetMatches())
+	}
+
+	_, err = c.Match(ctx, &pb.MatchRequest{Value: "Smythe", Candidates: []string{"a", "b", "c", "d"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("wanted InvalidArgument for too many candidates, got %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	s := New()
+	s.Stats = metaphone3.NewStats()
+	if _, err := s.Encode(context.Background(), &pb.EncodeRequest{Value: "Smith"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Match(context.Background(), &pb.MatchRequest{Value: "Smith", Candidates: []string{"Smyth"}}); err != nil {
+		t.Fatal(err)
+	}
+	if snap := s.Stats.Snapshot(); snap.Inputs != 4 {
+		t.Fatalf("wanted 4 inputs counted, got %+v", snap)
+	}
+}
@@ -0,0 +1,264 @@
+// Package index is an in-memory phonetic index of values, such as names, keyed by their
+// Metaphone 3 primary and secondary keys.  It finds values that sound like a query and
+// groups values that sound alike.
+package index
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/dlclark/metaphone3"
+)
+
+// Entry is a distinct value in the index.
+type Entry struct {
+	Value              string
+	Primary, Secondary string
+	// Count is the number of times the value was added
+	Count int
+	// IDs are the distinct non-blank ids the value was added with, in the order they were added.
+	// They share storage with the index so they must not be modified, appending is fine.
+	IDs []string
+}
+
+// MatchType is how a value matched, primary matches are the strongest.
+type MatchType int
+
+const (
+	// PrimaryMatch is a match between primary keys
+	PrimaryMatch MatchType = iota
+	// SecondaryMatch is a match that involves at least one secondary key
+	SecondaryMatch
+)
+
+func (m MatchType) String() string {
+	if m == PrimaryMatch {
+		return "primary"
+	}
+	return "secondary"
+}
+
+// Match is an entry that matched a query or belongs to a cluster.  Entry is a copy
+// taken when the index was queried, later adds don't change it.
+type Match struct {
+	Entry Entry
+	Type  MatchType
+}
+
+// Index is an in-memory phonetic index.  It's safe to use across goroutines.
+type Index struct {
+	mu      sync.Mutex
+	enc     *metaphone3.Encoder
+	entries map[string]*Entry
+	// the ids of each value, to skip repeats
+	ids map[string]map[string]struct{}
+	// entries by key, a value appears under its primary and its secondary
+	prim, sec map[string][]*Entry
+}
+
+// New returns an empty index that encodes values with the given options.  The index takes
+// ownership of the Encoder, it must not be used elsewhere.  If e is nil the default options are used.
+func New(e *metaphone3.Encoder) *Index {
+	if e == nil {
+		e = &metaphone3.Encoder{}
+	}
+	return &Index{
+		enc:     e,
+		entries: make(map[string]*Entry),
+		ids:     make(map[string]map[string]struct{}),
+		prim:    make(map[string][]*Entry),
+		sec:     make(map[string][]*Entry),
+	}
+}
+
+// ErrFull is returned by AddLimited when adding would pass one of the limits.
+var ErrFull = errors.New("index: full")
+
+// Limits caps the size of an index for AddLimited, a zero limit is no limit.
+type Limits struct {
+	// Values is the most distinct values in the index
+	Values int
+	// IDs is the most ids kept for each value
+	IDs int
+}
+
+// Add adds a value to the index along with an optional id and returns a copy of
+// its entry.  Adding a value again increases its count and records the new id.
+// Values that have no key (e.g. blank values) are ignored and false is returned.
+func (ix *Index) Add(value, id string) (Entry, bool) {
+	ent, ok, _ := ix.AddLimited(value, id, Limits{})
+	return ent, ok
+}
+
+// AddLimited is Add with limits on the size of the index.  If a new value or id would
+// pass a limit nothing is added, not even to the count, and ErrFull is returned.
+func (ix *Index) AddLimited(value, id string, l Limits) (Entry, bool, error) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ent, ok := ix.entries[value]
+	if !ok {
+		prim, sec := ix.enc.Encode(value)
+		if prim == "" && sec == "" {
+			return Entry{}, false, nil
+		}
+		if l.Values > 0 && len(ix.entries) >= l.Values {
+			return Entry{}, false, ErrFull
+		}
+		ent = &Entry{Value: value, Primary: prim, Secondary: sec}
+		ix.entries[value] = ent
+		if prim != "" {
+			ix.prim[prim] = append(ix.prim[prim], ent)
+		}
+		if sec != "" {
+			ix.sec[sec] = append(ix.sec[sec], ent)
+		}
+	}
+
+	if id != "" {
+		ids := ix.ids[value]
+		if ids == nil {
+			ids = make(map[string]struct{})
+			ix.ids[value] = ids
+		}
+		if _, ok := ids[id]; !ok {
+			if l.IDs > 0 && len(ids) >= l.IDs {
+				return Entry{}, false, ErrFull
+			}
+			ids[id] = struct{}{}
+			ent.IDs = append(ent.IDs, id)
+		}
+	}
+	ent.Count++
+	return ent.snapshot(), true, nil
+}
+
+// snapshot copies the entry so it can be handed out without holding the lock.  The ids
+// are only ever appended to, so the copy shares them up to its length and an append to
+// the copy's ids gets new storage.
+func (ent *Entry) snapshot() Entry {
+	s := *ent
+	s.IDs = ent.IDs[:len(ent.IDs):len(ent.IDs)]
+	return s
+}
+
+// Len returns the number of distinct values in the index.
+func (ix *Index) Len() int {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return len(ix.entries)
+}
+
+// Encode returns the keys for a value using the options of the index.
+func (ix *Index) Encode(value string) (primary, secondary string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return ix.enc.Encode(value)
+}
+
+// Search returns the entries that sound like the value.  Primary matches come first,
+// then secondary matches, and within each the most frequent values come first.
+func (ix *Index) Search(value string) []Match {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	prim, sec := ix.enc.Encode(value)
+
+	var out []Match
+	seen := make(map[*Entry]bool)
+	add := func(ents []*Entry, t MatchType) {
+		for _, ent := range ents {
+			if !seen[ent] {
+				seen[ent] = true
+				out = append(out, Match{Entry: ent.snapshot(), Type: t})
+			}
+		}
+	}
+
+	if prim != "" {
+		add(ix.prim[prim], PrimaryMatch)
+		add(ix.sec[prim], SecondaryMatch)
+	}
+	if sec != "" {
+		add(ix.prim[sec], SecondaryMatch)
+		add(ix.sec[sec], SecondaryMatch)
+	}
+
+	sortMatches(out)
+	return out
+}
+
+// Cluster is a group of values that share a key.
+type Cluster struct {
+	Key string
+	// Members have Type PrimaryMatch if the key is their primary and SecondaryMatch
+	// if it's their secondary, ordered the same way as search results
+	Members []Match
+}
+
+// Count returns the total count of all the members.
+func (c Cluster) Count() int {
+	n := 0
+	for _, m := range c.Members {
+		n += m.Entry.Count
+	}
+	return n
+}
+
+// Clusters returns the groups of at least minSize distinct values that share a key, most
+// frequent clusters first.  A value with a secondary can be in two clusters.
+func (ix *Index) Clusters(minSize int) []Cluster {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	var out []Cluster
+	addCluster := func(key string) {
+		c := Cluster{Key: key}
+		for _, ent := range ix.prim[key] {
+			c.Members = append(c.Members, Match{Entry: ent.snapshot(), Type: PrimaryMatch})
+		}
+		for _, ent := range ix.sec[key] {
+			c.Members = append(c.Members, Match{Entry: ent.snapshot(), Type: SecondaryMatch})
+		}
+		if len(c.Members) >= minSize && len(c.Members) > 0 {
+			sortMatches(c.Members)
+			out = append(out, c)
+		}
+	}
+
+	for key := range ix.prim {
+		addCluster(key)
+	}
+	for key := range ix.sec {
+		if _, ok := ix.prim[key]; !ok {
+			addCluster(key)
+		}
+	}
+
+	counts := make(map[string]int, len(out))
+	for _, c := range out {
+		counts[c.Key] = c.Count()
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if ci, cj := counts[out[i].Key], counts[out[j].Key]; ci != cj {
+			return ci > cj
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// sortMatches orders by match type, then most frequent, then by value
+func sortMatches(ms []Match) {
+	sort.Slice(ms, func(i, j int) bool {
+		a, b := ms[i], ms[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Entry.Count != b.Entry.Count {
+			return a.Entry.Count > b.Entry.Count
+		}
+		return a.Entry.Value < b.Entry.Value
+	})
+}
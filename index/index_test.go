@@ -0,0 +1,135 @@
+package index
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestIndex() *Index {
+	ix := New(nil)
+	for _, v := range []string{"Smith", "Smyth", "Smith", "Schmidt", "Smithe", "Jones", "", "Smith"} {
+		ix.Add(v, "")
+	}
+	return ix
+}
+
+func matchStrings(ms []Match) []string {
+	var out []string
+	for _, m := range ms {
+		out = append(out, m.Entry.Value+":"+m.Type.String())
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	ix := newTestIndex()
+	if want, got := 5, ix.Len(); want != got {
+		t.Fatalf("wanted %v entries, got %v", want, got)
+	}
+
+	want := []string{"Smith:primary", "Smithe:primary", "Smyth:primary", "Schmidt:secondary"}
+	if got := matchStrings(ix.Search("Smythe")); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	want = []string{"Schmidt:primary", "Smith:secondary", "Smithe:secondary", "Smyth:secondary"}
+	if got := matchStrings(ix.Search("Schmitt")); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	if got := ix.Search("Garcia"); len(got) != 0 {
+		t.Fatalf("wanted no matches, got %v", matchStrings(got))
+	}
+}
+
+func TestAdd_IDs(t *testing.T) {
+	ix := New(nil)
+	ix.Add("Smith", "1")
+	ix.Add("Smith", "2")
+	ent, ok := ix.Add("Smith", "1")
+	if !ok {
+		t.Fatal("wanted Smith to be added")
+	}
+
+	if want, got := []string{"1", "2"}, ent.IDs; !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted ids %v, got %v", want, got)
+	}
+	if want, got := 3, ent.Count; want != got {
+		t.Fatalf("wanted count %v, got %v", want, got)
+	}
+	if _, ok := ix.Add("", "3"); ok {
+		t.Fatal("wanted a blank value to be ignored")
+	}
+}
+
+func TestClusters(t *testing.T) {
+	cs := newTestIndex().Clusters(2)
+	if want, got := 2, len(cs); want != got {
+		t.Fatalf("wanted %v clusters, got %v", want, got)
+	}
+
+	if want, got := "XMT", cs[0].Key; want != got {
+		t.Fatalf("wanted first cluster %v, got %v", want, got)
+	}
+	if want, got := []string{"Schmidt:primary", "Smith:secondary", "Smithe:secondary", "Smyth:secondary"}, matchStrings(cs[0].Members); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+	if want, got := 6, cs[0].Count(); want != got {
+		t.Fatalf("wanted first cluster count %v, got %v", want, got)
+	}
+
+	if want, got := "SM0", cs[1].Key; want != got {
+		t.Fatalf("wanted second cluster %v, got %v", want, got)
+	}
+	if want, got := []string{"Smith:primary", "Smithe:primary", "Smyth:primary"}, matchStrings(cs[1].Members); !reflect.DeepEqual(want, got) {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	ix := New(nil)
+	ent, _ := ix.Add("Smith", "1")
+	ms := ix.Search("Smith")
+	cs := ix.Clusters(1)
+	ix.Add("Smith", "2")
+
+	if ent.Count != 1 || len(ent.IDs) != 1 {
+		t.Fatalf("added entry changed to %+v", ent)
+	}
+	if e := ms[0].Entry; e.Count != 1 || len(e.IDs) != 1 {
+		t.Fatalf("search result changed to %+v", e)
+	}
+	if e := cs[0].Members[0].Entry; e.Count != 1 || len(e.IDs) != 1 {
+		t.Fatalf("cluster member changed to %+v", e)
+	}
+
+	// appending to a copy's ids doesn't change the index
+	ent.IDs = append(ent.IDs, "x")
+	if ent, _ := ix.Add("Smith", "3"); !reflect.DeepEqual(ent.IDs, []string{"1", "2", "3"}) {
+		t.Fatalf("wanted ids 1, 2 and 3, got %v", ent.IDs)
+	}
+}
+
+func TestAddLimited(t *testing.T) {
+	ix := New(nil)
+	l := Limits{Values: 1, IDs: 2}
+	if _, ok, err := ix.AddLimited("Smith", "1", l); !ok || err != nil {
+		t.Fatalf("wanted Smith to be added, got %v %v", ok, err)
+	}
+	if _, _, err := ix.AddLimited("Jones", "", l); !errors.Is(err, ErrFull) {
+		t.Fatalf("wanted ErrFull for a second value, got %v", err)
+	}
+	if _, ok, err := ix.AddLimited("", "", l); ok || err != nil {
+		t.Fatalf("wanted a blank value to be ignored, got %v %v", ok, err)
+	}
+
+	ix.AddLimited("Smith", "2", l)
+	if _, _, err := ix.AddLimited("Smith", "3", l); !errors.Is(err, ErrFull) {
+		t.Fatalf("wanted ErrFull for a third id, got %v", err)
+	}
+	ent, _, err := ix.AddLimited("Smith", "1", l)
+	if err != nil || ent.Count != 3 || !reflect.DeepEqual(ent.IDs, []string{"1", "2"}) {
+		t.Fatalf("wanted count 3 and ids 1 and 2, got %+v %v", ent, err)
+	}
+}
@@ -0,0 +1,118 @@
+package metaphone3
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// InputPolicy controls what the Encoder does with runes that aren't letters, such as
+// punctuation, spaces and digits.
+type InputPolicy int
+
+const (
+	// InputSkip leaves non-letters in place.  The rules skip over them but still see them
+	// when looking around a letter, so "Smith-Jones" isn't encoded the same as "Smith Jones".
+	// This is the default and matches the Java implementation.
+	InputSkip InputPolicy = iota
+	// InputStrip removes non-letters before the rules run, "O'Brien" is encoded as "OBRIEN"
+	// and "Smith-Jones" as "SMITHJONES".
+	InputStrip
+	// InputSeparate treats runs of non-letters as word separators and encodes each word on
+	// its own, so rules for the start and end of a word apply to every word.  The keys of
+	// the words are joined together.  Apostrophes are removed rather than separating words,
+	// so "O'Brien" is one word.
+	InputSeparate
+	// InputDigits is InputSeparate with each digit spelled out as a word first,
+	// so "3M" is encoded as "THREE M".
+	InputDigits
+	// InputReject fails inputs that have anything but letters and apostrophes (which are
+	// removed).  Encode returns blank keys and TryEncode returns ErrNonLetter.
+	InputReject
+)
+
+var inputPolicyNames = [...]string{"skip", "strip", "separate", "digits", "reject"}
+
+func (p InputPolicy) String() string {
+	if p >= 0 && int(p) < len(inputPolicyNames) {
+		return inputPolicyNames[p]
+	}
+	return fmt.Sprintf("InputPolicy(%d)", int(p))
+}
+
+// ParseInputPolicy returns the policy for a name returned by InputPolicy.String, e.g. "strip".
+func ParseInputPolicy(name string) (InputPolicy, error) {
+	for i, n := range inputPolicyNames {
+		if n == name {
+			return InputPolicy(i), nil
+		}
+	}
+	return InputSkip, fmt.Errorf("metaphone3: unknown input policy %q", name)
+}
+
+// ErrNonLetter is returned by TryEncode with InputReject when the input has a rune that
+// isn't a letter
+var ErrNonLetter = errors.New("metaphone3: input has a character that isn't a letter")
+
+var digitWords = [10]string{"ZERO", "ONE", "TWO", "THREE", "FOUR", "FIVE", "SIX", "SEVEN", "EIGHT", "NINE"}
+
+// isApostrophe returns true for the apostrophes found inside names like O'Brien
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// prepareInput upper cases the input into e.text and applies the input policy.  With
+// InputSeparate and InputDigits words are left separated by a single space.
+func (e *Encoder) prepareInput(in string) error {
+	e.text = e.text[:0]
+
+	switch e.InputPolicy {
+	case InputStrip:
+		for _, r := range in {
+			if unicode.IsLetter(r) {
+				e.text = append(e.text, unicode.ToUpper(r))
+			}
+		}
+
+	case InputSeparate, InputDigits:
+		space := false
+		for _, r := range in {
+			switch {
+			case unicode.IsLetter(r):
+				if space && len(e.text) > 0 {
+					e.text = append(e.text, ' ')
+				}
+				space = false
+				e.text = append(e.text, unicode.ToUpper(r))
+			case isApostrophe(r):
+			case e.InputPolicy == InputDigits && r >= '0' && r <= '9':
+				if len(e.text) > 0 {
+					e.text = append(e.text, ' ')
+				}
+				for _, c := range digitWords[r-'0'] {
+					e.text = append(e.text, c)
+				}
+				space = true
+			default:
+				space = true
+			}
+		}
+
+	case InputReject:
+		for _, r := range in {
+			if unicode.IsLetter(r) {
+				e.text = append(e.text, unicode.ToUpper(r))
+			} else if !isApostrophe(r) {
+				e.text = e.text[:0]
+				return ErrNonLetter
+			}
+		}
+
+	default:
+		for _, r := range in {
+			e.text = append(e.text, unicode.ToUpper(r))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package metaphone3
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInputPolicy(t *testing.T) {
+	tests := []struct {
+		policy    InputPolicy
+		in        string
+		prim, sec string
+	}{
+		// non-letters are skipped in place, so a lone vowel isn't at the start
+		{InputSkip, "--a--", "", ""},
+		{InputSkip, "Smith Jones", "SM0JNS", "XMTJNS"},
+		{InputSkip, "3M", "M", ""},
+
+		{InputStrip, "--a--", "A", ""},
+		{InputStrip, "O'Brien", "APRN", ""},
+		{InputStrip, "Smith-Jones", "SM0JNS", "XMTJNS"},
+
+		// each word gets the rules for the start of a word, e.g. J as Y in Jones
+		{InputSeparate, "Smith-Jones", "SM0JNS", "XMTANS"},
+		{InputSeparate, "Smith  Jones", "SM0JNS", "XMTANS"},
+		{InputSeparate, "O'Brien", "APRN", ""},
+		{InputSeparate, "Louis XIV", "LSSF", "LSF"},
+		{InputSeparate, "3M", "M", ""},
+
+		{InputDigits, "3M", "0RM", ""},
+		{InputDigits, "Smith-Jones", "SM0JNS", "XMTANS"},
+
+		{InputReject, "O'Brien", "APRN", ""},
+		{InputReject, "Smith", "SM0", "XMT"},
+	}
+
+	for _, test := range tests {
+		e := &Encoder{InputPolicy: test.policy}
+		prim, sec, err := e.TryEncode(test.in)
+		if err != nil {
+			t.Errorf("%v %q: unexpected error %v", test.policy, test.in, err)
+		}
+		if prim != test.prim || sec != test.sec {
+			t.Errorf("%v %q: wanted %v/%v, got %v/%v", test.policy, test.in, test.prim, test.sec, prim, sec)
+		}
+	}
+}
+
+func TestInputPolicy_Reject(t *testing.T) {
+	e := &Encoder{InputPolicy: InputReject}
+	for _, in := range []string{"Smith-Jones", "Louis XIV", "3M", " "} {
+		if _, _, err := e.TryEncode(in); !errors.Is(err, ErrNonLetter) {
+			t.Errorf("%q: wanted ErrNonLetter, got %v", in, err)
+		}
+		if prim, sec := e.Encode(in); prim != "" || sec != "" {
+			t.Errorf("%q: wanted blank keys, got %v/%v", in, prim, sec)
+		}
+		if all := e.EncodeAll(in); all != nil {
+			t.Errorf("%q: wanted no alternates, got %v", in, all)
+		}
+	}
+
+	// the error doesn't stick to the encoder
+	if _, _, err := e.TryEncode("Smith"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestInputPolicy_Exceptions(t *testing.T) {
+	x := NewExceptions()
+	x.AddWord("Siobhan", Exception{Primary: "XFN"})
+
+	// exceptions apply to each word when separating
+	e := &Encoder{InputPolicy: InputSeparate, Exceptions: x}
+	if want, got := "XFNMRF", encodePrim(e, "Siobhan Murphy"); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func encodePrim(e *Encoder, in string) string {
+	prim, _ := e.Encode(in)
+	return prim
+}
+
+func TestParseInputPolicy(t *testing.T) {
+	for p := InputSkip; p <= InputReject; p++ {
+		got, err := ParseInputPolicy(p.String())
+		if err != nil || got != p {
+			t.Errorf("%v: got %v %v", p, got, err)
+		}
+	}
+	if _, err := ParseInputPolicy("nope"); err == nil {
+		t.Fatal("wanted an error for an unknown policy")
+	}
+}
@@ -0,0 +1,54 @@
+// Package pools keeps a metaphone3.Pool for each set of options that the servers and
+// language bindings are asked for, so every request with the same options shares encoders.
+package pools
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dlclark/metaphone3"
+)
+
+// MaxLength is the largest max length a caller can ask for, FullLength gives longer keys.
+// It bounds the buffers an encoder keeps and the number of pools.
+const MaxLength = 64
+
+// ErrMaxLength is returned for a max length that's negative or over MaxLength.
+var ErrMaxLength = fmt.Errorf("max length must be between 0 and %v", MaxLength)
+
+// Options are the encoder options that can vary between callers.
+type Options struct {
+	Vowels, Exact, FullLength bool
+	// MaxLength is 0 for the default
+	MaxLength int
+	Input     metaphone3.InputPolicy
+	// Stats is shared by the encoders of the pool, if not nil
+	Stats *metaphone3.Stats
+}
+
+// Cache has a Pool for each Options.  The zero value is ready to use and it's safe to
+// use across goroutines.
+type Cache struct {
+	// pools by Options
+	pools sync.Map
+}
+
+// Get returns the pool for the options, it returns ErrMaxLength if the max length is out of range.
+func (c *Cache) Get(o Options) (*metaphone3.Pool, error) {
+	if o.MaxLength < 0 || o.MaxLength > MaxLength {
+		return nil, ErrMaxLength
+	}
+
+	if p, ok := c.pools.Load(o); ok {
+		return p.(*metaphone3.Pool), nil
+	}
+	p, _ := c.pools.LoadOrStore(o, metaphone3.NewPool(&metaphone3.Encoder{
+		EncodeVowels: o.Vowels,
+		EncodeExact:  o.Exact,
+		MaxLength:    o.MaxLength,
+		FullLength:   o.FullLength,
+		InputPolicy:  o.Input,
+		Stats:        o.Stats,
+	}))
+	return p.(*metaphone3.Pool), nil
+}
@@ -0,0 +1,36 @@
+package pools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dlclark/metaphone3"
+)
+
+func TestCache(t *testing.T) {
+	var c Cache
+	p, err := c.Get(Options{Vowels: true, MaxLength: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prim, sec := p.Encode("Villafranca"); prim != "FALA" || sec != "FAFR" {
+		t.Fatalf("wanted FALA/FAFR, got %v/%v", prim, sec)
+	}
+	if p2, _ := c.Get(Options{Vowels: true, MaxLength: 4}); p2 != p {
+		t.Fatal("wanted the same pool for the same options")
+	}
+	if p2, _ := c.Get(Options{Vowels: true}); p2 == p {
+		t.Fatal("wanted a new pool for new options")
+	}
+
+	p, _ = c.Get(Options{FullLength: true, Input: metaphone3.InputReject})
+	if _, _, err := p.TryEncode("Smith3"); !errors.Is(err, metaphone3.ErrNonLetter) {
+		t.Fatalf("wanted ErrNonLetter, got %v", err)
+	}
+
+	for _, n := range []int{-1, MaxLength + 1} {
+		if _, err := c.Get(Options{MaxLength: n}); !errors.Is(err, ErrMaxLength) {
+			t.Errorf("max length %v: wanted ErrMaxLength, got %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package metaphone3
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Key is the primary and secondary metaphones for an input along with the options
+// they were encoded with.  Secondary is blank when there's only one metaphone.
+//
+// A Key is stored as a single string, e.g. "SM0|XMT|8", by its TextMarshaler and
+// database/sql Valuer, so the options are kept with the metaphones.
+type Key struct {
+	Primary, Secondary string
+	Options            KeyOptions
+}
+
+// KeyOptions are the encoder options that change the metaphones.  Keys are only
+// comparable when they have the same options.  Exceptions aren't included.
+type KeyOptions struct {
+	EncodeVowels, EncodeExact bool
+	// MaxLength is the max length of the metaphones, 0 for full length keys
+	MaxLength   int
+	InputPolicy InputPolicy
+}
+
+// keyOptions returns the options of the current settings of the encoder
+func (e *Encoder) keyOptions() KeyOptions {
+	o := KeyOptions{
+		EncodeVowels: e.EncodeVowels,
+		EncodeExact:  e.EncodeExact,
+		MaxLength:    e.MaxLength,
+		InputPolicy:  e.InputPolicy,
+	}
+	if e.FullLength {
+		o.MaxLength = 0
+	} else if o.MaxLength <= 0 {
+		o.MaxLength = DefaultMaxLength
+	}
+	return o
+}
+
+// EncodeKey is Encode returning a Key.
+func (e *Encoder) EncodeKey(in string) Key {
+	prim, sec := e.Encode(in)
+	return Key{Primary: prim, Secondary: sec, Options: e.keyOptions()}
+}
+
+// Truncate returns the key cut to at most n runes.  Truncating a FullLength key gives
+// the same key as encoding the input with MaxLength n, so one stored key can be
+// compared at several lengths.  The secondary is blank if it becomes the same as
+// the primary.  If n <= 0 the key is returned unchanged.
+//
+// Supplement Exceptions break this: a supplement is only the secondary when the keys
+// are the same at the length they're encoded at, so e.g. with a supplement for
+// Abramovich, MaxLength 4 gives APRM and the supplement while the full length key
+// cut to 4 has no secondary.
+func (k Key) Truncate(n int) Key {
+	if n <= 0 {
+		return k
+	}
+	k.Primary = truncateRunes(k.Primary, n)
+	k.Secondary = truncateRunes(k.Secondary, n)
+	if k.Secondary == k.Primary {
+		k.Secondary = ""
+	}
+	if k.Options.MaxLength == 0 || n < k.Options.MaxLength {
+		k.Options.MaxLength = n
+	}
+	return k
+}
+
+// HasPrefix returns true if the primary or secondary starts with prefix.  A blank
+// prefix only matches a blank key.
+func (k Key) HasPrefix(prefix string) bool {
+	if prefix == "" {
+		return k.Primary == ""
+	}
+	return strings.HasPrefix(k.Primary, prefix) || (k.Secondary != "" && strings.HasPrefix(k.Secondary, prefix))
+}
+
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// String returns the fingerprint of the options: "V" for EncodeVowels, "E" for
+// EncodeExact, then the max length or "F" for full length keys, then "/" and the
+// input policy if it isn't InputSkip.  E.g. "8", "VE4", "F/strip".
+func (o KeyOptions) String() string {
+	var sb strings.Builder
+	if o.EncodeVowels {
+		sb.WriteByte('V')
+	}
+	if o.EncodeExact {
+		sb.WriteByte('E')
+	}
+	if o.MaxLength == 0 {
+		sb.WriteByte('F')
+	} else {
+		sb.WriteString(strconv.Itoa(o.MaxLength))
+	}
+	if o.InputPolicy != InputSkip {
+		sb.WriteByte('/')
+		sb.WriteString(o.InputPolicy.String())
+	}
+	return sb.String()
+}
+
+// ParseKeyOptions parses an options fingerprint from KeyOptions.String.
+func ParseKeyOptions(s string) (KeyOptions, error) {
+	var o KeyOptions
+	fp := s
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		p, err := ParseInputPolicy(s[i+1:])
+		if err != nil {
+			return KeyOptions{}, fmt.Errorf("metaphone3: invalid key options %q", fp)
+		}
+		o.InputPolicy, s = p, s[:i]
+	}
+	if strings.HasPrefix(s, "V") {
+		o.EncodeVowels, s = true, s[1:]
+	}
+	if strings.HasPrefix(s, "E") {
+		o.EncodeExact, s = true, s[1:]
+	}
+	if s != "F" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || s[0] == '+' {
+			return KeyOptions{}, fmt.Errorf("metaphone3: invalid key options %q", fp)
+		}
+		o.MaxLength = n
+	}
+	return o, nil
+}
+
+// keySep separates the parts of the text form of a key
+const keySep = "|"
+
+// MarshalText returns the key as primary|secondary|options, e.g. "SM0|XMT|8".
+// It fails if the metaphones have a "|", which only happens with exceptions.
+func (k Key) MarshalText() ([]byte, error) {
+	if strings.Contains(k.Primary, keySep) || strings.Contains(k.Secondary, keySep) {
+		return nil, fmt.Errorf("metaphone3: key %q/%q contains %q", k.Primary, k.Secondary, keySep)
+	}
+	return []byte(k.Primary + keySep + k.Secondary + keySep + k.Options.String()), nil
+}
+
+// UnmarshalText parses the text form from MarshalText.
+func (k *Key) UnmarshalText(b []byte) error {
+	parts := strings.Split(string(b), keySep)
+	if len(parts) != 3 {
+		return fmt.Errorf("metaphone3: invalid key %q", b)
+	}
+	o, err := ParseKeyOptions(parts[2])
+	if err != nil {
+		return err
+	}
+	*k = Key{Primary: parts[0], Secondary: parts[1], Options: o}
+	return nil
+}
+
+// jsonKey is the JSON form of a Key
+type jsonKey struct {
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Options   string `json:"options"`
+}
+
+// MarshalJSON returns the key as an object, e.g. {"primary":"SM0","secondary":"XMT","options":"8"}.
+func (k Key) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonKey{k.Primary, k.Secondary, k.Options.String()})
+}
+
+// UnmarshalJSON parses the object from MarshalJSON or a string in the text form.
+func (k *Key) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		return k.UnmarshalText([]byte(s))
+	}
+
+	var j jsonKey
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	o, err := ParseKeyOptions(j.Options)
+	if err != nil {
+		return err
+	}
+	*k = Key{Primary: j.Primary, Secondary: j.Secondary, Options: o}
+	return nil
+}
+
+// Value stores the key in a text column in the form from MarshalText.
+func (k Key) Value() (driver.Value, error) {
+	b, err := k.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan reads a key stored by Value, a NULL becomes the zero Key.
+func (k *Key) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*k = Key{}
+		return nil
+	case string:
+		return k.UnmarshalText([]byte(v))
+	case []byte:
+		return k.UnmarshalText(v)
+	}
+	return fmt.Errorf("metaphone3: can't scan a key from %T", src)
+}
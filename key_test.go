@@ -0,0 +1,190 @@
+package metaphone3
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestKey_Truncate(t *testing.T) {
+	k := Key{Primary: "SM0JNS", Secondary: "XMTANS", Options: KeyOptions{MaxLength: 8}}
+	if want, got := (Key{"SM0", "XMT", KeyOptions{MaxLength: 3}}), k.Truncate(3); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+	if want, got := k, k.Truncate(0); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	// the secondary is dropped once it's the same as the primary
+	k = Key{Primary: "JNS", Secondary: "JNT"}
+	if want, got := (Key{"JN", "", KeyOptions{MaxLength: 2}}), k.Truncate(2); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	if want, got := "ÀB", truncateRunes("ÀBC", 2); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+}
+
+func TestKey_HasPrefix(t *testing.T) {
+	k := Key{Primary: "SM0", Secondary: "XMT"}
+	for _, p := range []string{"S", "SM", "SM0", "X", "XMT"} {
+		if !k.HasPrefix(p) {
+			t.Errorf("wanted %v to have prefix %v", k, p)
+		}
+	}
+	for _, p := range []string{"M", "SM0A", ""} {
+		if k.HasPrefix(p) {
+			t.Errorf("wanted %v not to have prefix %v", k, p)
+		}
+	}
+}
+
+func TestEncoder_FullLength(t *testing.T) {
+	e := &Encoder{FullLength: true}
+	if want, got := (Key{"ALKSNTRPFLFSKF", "", KeyOptions{}}), e.EncodeKey("Alexandropavlovskov"); want != got {
+		t.Fatalf("wanted %v, got %v", want, got)
+	}
+
+	// the options aren't changed by encoding
+	e = &Encoder{}
+	e.Encode("Smith")
+	if e.MaxLength != 0 {
+		t.Fatalf("wanted MaxLength to stay 0, got %v", e.MaxLength)
+	}
+}
+
+// TestKey_TruncateCorpus checks that truncating full length keys gives the same keys
+// as encoding with a max length
+func TestKey_TruncateCorpus(t *testing.T) {
+	var words []string
+	for _, file := range []string{"testdata/firstnames-us.txt", "testdata/surnames-us.txt"} {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			words = append(words, scanner.Text())
+		}
+		f.Close()
+	}
+	if testing.Short() {
+		words = words[:2000]
+	}
+
+	for _, opts := range []Encoder{{}, {EncodeVowels: true}, {EncodeExact: true}, {EncodeVowels: true, EncodeExact: true}} {
+		full := opts
+		full.FullLength = true
+		for _, n := range []int{4, 8} {
+			short := opts
+			short.MaxLength = n
+			for _, w := range words {
+				if want, got := short.EncodeKey(w), full.EncodeKey(w).Truncate(n); want != got {
+					t.Fatalf("%q vowels=%v exact=%v: wanted %v at length %v, got %v", w, opts.EncodeVowels, opts.EncodeExact, want, n, got)
+				}
+			}
+		}
+	}
+}
+
+func TestKeyOptions_String(t *testing.T) {
+	tests := []struct {
+		o  KeyOptions
+		fp string
+	}{
+		{KeyOptions{MaxLength: 8}, "8"},
+		{KeyOptions{EncodeVowels: true, EncodeExact: true, MaxLength: 4}, "VE4"},
+		{KeyOptions{EncodeExact: true, MaxLength: 12}, "E12"},
+		{KeyOptions{InputPolicy: InputStrip}, "F/strip"},
+	}
+	for _, test := range tests {
+		if got := test.o.String(); got != test.fp {
+			t.Errorf("%+v: wanted %v, got %v", test.o, test.fp, got)
+		}
+		o, err := ParseKeyOptions(test.fp)
+		if err != nil || o != test.o {
+			t.Errorf("%v: wanted %+v, got %+v %v", test.fp, test.o, o, err)
+		}
+	}
+
+	for _, bad := range []string{"", "V", "EV8", "0", "-1", "+8", "8/nope", "8x"} {
+		if _, err := ParseKeyOptions(bad); err == nil {
+			t.Errorf("%q: wanted an error", bad)
+		}
+	}
+}
+
+func TestKey_RoundTrip(t *testing.T) {
+	keys := []Key{
+		(&Encoder{}).EncodeKey("Smith"),
+		(&Encoder{EncodeVowels: true, EncodeExact: true, MaxLength: 4}).EncodeKey("Villafranca"),
+		(&Encoder{FullLength: true, InputPolicy: InputSeparate}).EncodeKey("Smith-Jones"),
+		(&Encoder{}).EncodeKey(""),
+		{},
+	}
+
+	for _, k := range keys {
+		b, err := k.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var text Key
+		if err := text.UnmarshalText(b); err != nil || text != k {
+			t.Errorf("text %s: wanted %+v, got %+v %v", b, k, text, err)
+		}
+
+		j, err := json.Marshal(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fromJSON Key
+		if err := json.Unmarshal(j, &fromJSON); err != nil || fromJSON != k {
+			t.Errorf("json %s: wanted %+v, got %+v %v", j, k, fromJSON, err)
+		}
+
+		v, err := k.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, src := range []any{v, []byte(v.(string))} {
+			var scanned Key
+			if err := scanned.Scan(src); err != nil || scanned != k {
+				t.Errorf("sql %v: wanted %+v, got %+v %v", v, k, scanned, err)
+			}
+		}
+	}
+}
+
+func TestKey_Formats(t *testing.T) {
+	k := (&Encoder{}).EncodeKey("Smith")
+
+	if v, _ := k.Value(); v != "SM0|XMT|8" {
+		t.Fatalf("wanted SM0|XMT|8, got %v", v)
+	}
+	if j, _ := json.Marshal(k); string(j) != `{"primary":"SM0","secondary":"XMT","options":"8"}` {
+		t.Fatalf("unexpected json %s", j)
+	}
+
+	// keys in structs are marshaled as objects and can be read back from strings
+	var s struct{ Key Key }
+	if err := json.Unmarshal([]byte(`{"Key":"SM0|XMT|8"}`), &s); err != nil || s.Key != k {
+		t.Fatalf("wanted %+v, got %+v %v", k, s.Key, err)
+	}
+
+	k.Primary = "SM|0"
+	if _, err := k.Value(); err == nil {
+		t.Fatal("wanted an error for a key containing the separator")
+	}
+
+	var scanned Key
+	if err := scanned.Scan(nil); err != nil || scanned != (Key{}) {
+		t.Fatalf("wanted NULL to scan as the zero Key, got %+v %v", scanned, err)
+	}
+	for _, bad := range []any{42, "SM0|XMT", "SM0|XMT|8|x", "SM0|XMT|Q"} {
+		if err := scanned.Scan(bad); err == nil {
+			t.Errorf("%v: wanted an error", bad)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package metaphone3
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+)
+
+// The long lists of words and names the rules look for are kept in data files
+// so they can be audited and extended without touching the rules.
+//
+//go:embed data/*.txt
+var dataFiles embed.FS
+
+var (
+	encodeGreekChInitialWords               = mustLoadWordList("data/encode_greek_ch_initial.txt")
+	encodeGreekChNonInitialWords1           = mustLoadWordList("data/encode_greek_ch_non_initial_1.txt")
+	encodeGreekChNonInitialWords2           = mustLoadWordList("data/encode_greek_ch_non_initial_2.txt")
+	initialGSoftWords1                      = mustLoadWordList("data/initial_g_soft_1.txt")
+	initialGSoftWords2                      = mustLoadWordList("data/initial_g_soft_2.txt")
+	encodeNgerWords                         = mustLoadWordList("data/encode_nger.txt")
+	encodeGerWords                          = mustLoadWordList("data/encode_ger.txt")
+	encodeNonInitialGFrontVowelWords        = mustLoadWordList("data/encode_non_initial_g_front_vowel.txt")
+	internalHardGenGinGetGitWords           = mustLoadWordList("data/internal_hard_gen_gin_get_git.txt")
+	encodeSpanishJWords                     = mustLoadWordList("data/encode_spanish_j.txt")
+	namesBeginningWithJThatGetAltYWords     = mustLoadWordList("data/names_beginning_with_j_that_get_alt_y.txt")
+	encodeVowelLeTranspositionWords         = mustLoadWordList("data/encode_vowel_le_transposition.txt")
+	encodePhWords                           = mustLoadWordList("data/encode_ph.txt")
+	testSilentRWords                        = mustLoadWordList("data/test_silent_r.txt")
+	encodeShWords                           = mustLoadWordList("data/encode_sh.txt")
+	germanicOrSlavicNameBeginningWithWWords = mustLoadWordList("data/germanic_or_slavic_name_beginning_with_w.txt")
+	encodeESilentWords                      = mustLoadWordList("data/encode_e_silent.txt")
+	encodeEPronouncedAtEndWords             = mustLoadWordList("data/encode_e_pronounced_at_end.txt")
+	encodeSilentInternalEWords              = mustLoadWordList("data/encode_silent_internal_e.txt")
+	encodeESuffixWords                      = mustLoadWordList("data/encode_e_suffix.txt")
+	encodeEPronouncedExceptionsWords        = mustLoadWordList("data/encode_e_pronounced_exceptions.txt")
+)
+
+// wordList is a list of words compiled into a trie so that all the words
+// can be checked in one pass over the input
+type wordList struct {
+	name string
+	root trieNode
+
+	// first has a bit set for the first letter of every word, so most
+	// positions in the input can be ruled out without walking the trie.
+	// firstOther is set if any word starts with a letter outside 0-127.
+	first      [2]uint64
+	firstOther bool
+}
+
+// newWordList compiles the given words into a wordList.
+func newWordList(words ...string) *wordList {
+	l := &wordList{}
+	for _, w := range words {
+		l.add(w)
+	}
+	return l
+}
+
+type trieNode struct {
+	// word is set if a word ends at this node
+	word     bool
+	children []trieEdge
+}
+
+type trieEdge struct {
+	c    rune
+	node *trieNode
+}
+
+func (n *trieNode) child(c rune) *trieNode {
+	for i := range n.children {
+		if n.children[i].c == c {
+			return n.children[i].node
+		}
+	}
+	return nil
+}
+
+func (l *wordList) add(word string) {
+	for _, c := range word {
+		if c < 128 {
+			l.first[c>>6] |= 1 << (c & 63)
+		} else {
+			l.firstOther = true
+		}
+		break
+	}
+
+	n := &l.root
+	for _, c := range word {
+		next := n.child(c)
+		if next == nil {
+			next = &trieNode{}
+			n.children = append(n.children, trieEdge{c, next})
+		}
+		n = next
+	}
+	n.word = true
+}
+
+// mustLoadWordList reads a data file with one word per line, blank lines and
+// lines starting with '#' are ignored
+func mustLoadWordList(file string) *wordList {
+	b, err := dataFiles.ReadFile(file)
+	if err != nil {
+		panic(err)
+	}
+
+	l := newWordList()
+	l.name = file
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line != strings.ToUpper(line) {
+			panic(fmt.Sprintf("%v: %q must be all caps", file, line))
+		}
+		l.add(line)
+	}
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+// match walks the trie from the given index of the input and returns true if a word is found,
+// if toEnd is set the word must use all the remaining letters of the input
+func (l *wordList) match(in []rune, start int, toEnd bool) bool {
+	if start < 0 || start >= len(in) {
+		return false
+	}
+	if c := in[start]; c < 128 && l.first[c>>6]&(1<<(c&63)) == 0 {
+		return false
+	} else if c >= 128 && !l.firstOther {
+		return false
+	}
+
+	n := &l.root
+	for i := start; i < len(in); i++ {
+		if n = n.child(in[i]); n == nil {
+			return false
+		}
+		if n.word && (!toEnd || i == len(in)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// listAt returns true if one of the words in the list is located at the
+// relative offset (relative to current idx) given.
+func (e *Encoder) listAt(offset int, l *wordList) bool {
+	return l.match(e.in, e.idx+offset, false)
+}
+
+// listAtEnd returns true if one of the words in the list is located at the
+// relative offset (relative to current idx) given and uses all the remaining
+// letters of the input.
+func (e *Encoder) listAtEnd(offset int, l *wordList) bool {
+	return l.match(e.in, e.idx+offset, true)
+}
+
+// listAtStart returns true if the given offset is the start of the input and it starts
+// with one of the words in the list.
+func (e *Encoder) listAtStart(offset int, l *wordList) bool {
+	if offset != -e.idx {
+		return false
+	}
+	return e.listAt(offset, l)
+}
+
+// listStart returns true if the input starts with one of the words in the list.
+func (e *Encoder) listStart(l *wordList) bool {
+	return l.match(e.in, 0, false)
+}
+
+// listExact returns true if the input is exactly one of the words in the list.
+func (e *Encoder) listExact(l *wordList) bool {
+	return l.match(e.in, 0, true)
+}
@@ -0,0 +1,50 @@
+package metaphone3
+
+import "testing"
+
+func TestWordList_Match(t *testing.T) {
+	l := &wordList{}
+	for _, w := range []string{"SCH", "SCHMIDT", "ZZ"} {
+		l.add(w)
+	}
+
+	vals := []struct {
+		in    string
+		start int
+		toEnd bool
+		want  bool
+	}{
+		{"SCHMIDT", 0, false, true},
+		{"SCHMIDT", 0, true, true},
+		{"SCHMID", 0, true, false},
+		{"SCH", 0, true, true},
+		{"ASCHER", 1, false, true},
+		{"ASCHER", 1, true, false},
+		{"BUZZ", 2, true, true},
+		{"BUZZ", 3, false, false},
+		{"BUZZ", -1, false, false},
+		{"BUZZ", 4, false, false},
+	}
+
+	for _, v := range vals {
+		if got := l.match([]rune(v.in), v.start, v.toEnd); got != v.want {
+			t.Errorf("match '%v' at %v (toEnd %v) wanted %v, got %v", v.in, v.start, v.toEnd, v.want, got)
+		}
+	}
+}
+
+func TestWordList_Data(t *testing.T) {
+	if want, got := true, testListStart("WOLFGANG", germanicOrSlavicNameBeginningWithWWords); want != got {
+		t.Fatalf("listStart error, wanted %v got %v", want, got)
+	}
+	if want, got := false, testListStart("WOODS", germanicOrSlavicNameBeginningWithWWords); want != got {
+		t.Fatalf("listStart error, wanted %v got %v", want, got)
+	}
+}
+
+func testListStart(in string, l *wordList) bool {
+	e := &Encoder{}
+	e.in = []rune(in)
+	e.idx = 2
+	return e.listStart(l)
+}
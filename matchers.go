@@ -0,0 +1,155 @@
+package metaphone3
+
+// Compiled word lists for the rules, these are built once when the package is
+// initialized so that the rules don't need to scan the lists one word at a time.
+var (
+	encodeCaToSWords = newWordList(
+		"FACADE", "FRANCAIS", "FRANCAIX", "LINGUICA", "GONCALVES", "PROVENCAL")
+	encodeChToHWords = newWordList(
+		"AIM", "ETH", "ELM", "ASID", "AZAN", "UPPAH", "UTZPA", "ALLAH", "ALUTZ", "AMETZ", "ESHVAN",
+		"ADARIM", "ANUKAH", "ALLLOTH", "ANNUKAH", "AROSETH")
+	encodeChToXWords         = newWordList("OACH", "EACH", "EECH", "OUCH", "OOCH", "MUCH", "SUCH")
+	encodeEnglishChToKWords  = newWordList("EAR", "HEAD", "BACK", "HEART", "BELLY", "TOOTH")
+	encodeGermanicChToKWords = newWordList(
+		"ULRICH", "LFRICH", "LLRICH", "EMRICH", "ZURICH", "EYRICH")
+	encodeGermanicChToKWords2 = newWordList(
+		"L", "R", "N", "M", "B", "H", "F", "V", "W", " ")
+	encodeArchWords = newWordList(
+		"ARCHEA", "ARCHEG", "ARCHEO", "ARCHET", "ARCHEL", "ARCHES", "ARCHEP", "ARCHEM", "ARCHEN")
+	encodeArchWords2 = newWordList(
+		"ARCHER", "ARCHIE", "ARCHENEMY", "ARCHIBALD", "ARCHULETA", "ARCHAMBAU")
+	encodeArchWords3 = newWordList(
+		"EPARCH", "NOMARCH", "EXILARCH", "HIPPARCH", "MARCHESE", "ARISTARCH", "MARCHETTI")
+	encodeGreekChInitialWords2 = newWordList(
+		"CHAMOM", "CHARAC", "CHARIS", "CHARTO", "CHARTU", "CHARYB", "CHRIST", "CHEMIC", "CHILIA")
+	encodeGreekChInitialWords3 = newWordList(
+		"CHOR", "CHOL", "CHYM", "CHYL", "CHLO", "CHOS", "CHUS", "CHOE")
+	encodeGreekChNonInitialWords3 = newWordList(
+		"MELCH", "GLOCH", "TRACH", "TROCH", "BRACH", "SYNCH", "PSYCH", "STICH", "PULCH", "EPOCH")
+	encodeGreekChNonInitialWords4 = newWordList(
+		"TYCH", "TOCH", "BUCH", "MOCH", "CICH", "DICH", "NUCH", "EICH", "LOCH", "DOCH", "ZECH", "WYCH")
+	encodeGreekChNonInitialWords5 = newWordList(
+		"BRONCH", "STOICH", "STRYCH", "TELECH", "PLANCH", "CATECH", "MANICH", "MALACH", "BIANCH",
+		"DIDACH", "BRANCHIO", "BRANCHIF")
+	encodeCiWords  = newWordList("CIAN", "CIAL", "CIAO", "CIES", "CIOL", "CION")
+	encodeCiWords2 = newWordList(
+		"CIENT", "CIENC", "CIOUS", "CIATE", "CIATI", "CIATO", "CIABL", "CIARY")
+	encodeCiWords3       = newWordList("LUCIO", "SOCIO", "SOCIE", "MACIAS", "LUCIANO", "HACIENDA")
+	encodeGgWords        = newWordList("AGGIA", "OGGIA", "AGGIO", "EGGIO", "EGGIA", "IGGIO")
+	encodeSilentGhWords  = newWordList("B", "H", "D", "K", "W", "N", "P", "V")
+	encodeSilentGhWords2 = newWordList("IE", "EY", "ES", "ER", "ED", "TY")
+	encodeGhToFWords     = newWordList("C", "G", "L", "R", "T", "N", "S")
+	encodeGnWords        = newWordList(
+		"LIGNI", "LIGNO", "REGNA", "DIGNI", "WEGNE", "TIGNE", "RIGNE", "REGNE", "TIGNO", "SIGNAL",
+		"SIGNIF", "SIGNAT")
+	initialGSoftWords3 = newWordList("IGI", "IRA", "IBE", "AOL", "IDE", "IGL")
+	initialGSoftWords4 = newWordList(
+		"ILES", "INGI", "ISEL", "IBBER", "IBBET", "IBLET", "IBRAN", "IGOLO", "IRARD", "IGANT", "IRAFFE",
+		"EEWHIZ", "ILLETTE", "IBRALTA")
+	encodeNgerWords2 = newWordList(
+		"BOULANG", "SLESING", "KISSING", "DERRING", "BARRING", "PHALANGER")
+	encodeNgerWords3 = newWordList(
+		"FLINGER", "SLINGER", "STANGER", "STENGER", "KLINGER", "CLINGER")
+	encodeGerWords2 = newWordList("PAGER", "WAGER", "NIGER", "ROGER", "LEGER", "CAGER")
+	encodeGerWords3 = newWordList(
+		"KREIGER", "KRUEGER", "METZGER", "KRIEGER", "KROEGER", "STEIGER", "DRAEGER", "BUERGER",
+		"BOERGER", "FIBIGER")
+	encodeGelWords = newWordList(
+		"MANGEL", "WEIGEL", "FLUGEL", "RANGEL", "HAUGEN", "RIEGEL", "VOEGEL")
+	internalHardNgWords            = newWordList("CRING", "FRING", "ORANG", "TWING", "CHANG", "PHANG")
+	internalHardGenGinGetGitWords2 = newWordList(
+		"BEGET", "BEGIN", "HAGEN", "FAGIN", "BOGEN", "WIGIN", "NTGEN", "EIGEN", "WEGEN", "WAGEN")
+	internalHardGOpenSyllableWords = newWordList("FOGY", "POGY", "YOGI", "MAGEE", "MCGEE", "HAGIO")
+	internalHardGOtherWords        = newWordList(
+		"GETH", "GEAR", "GEIS", "GIRL", "GIVI", "GIVE", "GIFT", "GIRD", "GIRT", "GILV", "GILD", "GELD")
+	internalHardGOtherWords2 = newWordList(
+		"WEGE", "HAGE", "VOEGE", "BERGE", "HELGE", "INGEBORG", "CORREGIDOR")
+	encodeInitialSilentHWords    = newWordList("OUR", "ERB", "EIR", "ONOR", "ONOUR", "ONEST")
+	encodeNonInitialSilentHWords = newWordList(
+		"NIHIL", "VEHEM", "LOHEN", "NEHEM", "MAHON", "MAHAN", "COHEN", "GAHAN")
+	encodeNonInitialSilentHWords2 = newWordList(
+		"TOUHY", "GRAHAM", "PROHIB", "FRAHER", "TOOHEY", "TOUHEY")
+	encodeSpanishJWords2  = newWordList("UAN", "ACI", "ALI", "EFE", "ICA", "IME", "OAQ", "UAR")
+	encodeSpanishJWords3  = newWordList("EREZ", "UNTA", "AIME", "AVIE", "AVIA", "IMINEZ", "ARAMIL")
+	encodeSpanishJ2Words  = newWordList("BOJA", "BAJA", "BEJA", "BOJO", "MOJA", "MOJI", "MEJI")
+	encodeSpanishJ2Words2 = newWordList(
+		"FRIJO", "BRUJO", "BRUJA", "GRAJE", "GRIJA", "LEIJA", "QUIJA")
+	encodeSpanishJ2Words3 = newWordList(
+		"AJOS", "EJOS", "OJAS", "OJOS", "UJON", "AJOZ", "AJAL", "UJAR", "EJON", "EJAN", "AJARA")
+	encodeJAsVowelWords  = newWordList("L", "T", "K", "S", "N", "M")
+	encodeJAsVowelWords2 = newWordList(
+		"FJ", "WOJ", "LJUB", "BJOR", "HAJEK", "HALLELUJA", "LJUBLJANA")
+	encodeFrenchAultWords    = newWordList("RAULT", "NAULT", "BAULT", "SAULT", "GAULT", "CAULT")
+	encodeSilentLInLkLvWords = newWordList(
+		"WALK", "YOLK", "FOLK", "HALF", "TALK", "CALF", "BALK", "CALK")
+	encodeSilentLInLkLvWords2 = newWordList("POLKA", "PALKO", "HALVA", "HALVO", "SALVER", "CALVER")
+	encodeLlAsVowelWords      = newWordList(
+		"LLA", "VILLE", "VILLA", "GALLARDO", "VALLADAR", "MAGALLAN", "CAVALLAR", "BALLASTE")
+	encodeVowelLeTranspositionWords2 = newWordList(
+		"MCCLE", "MCLEL", "EMBLEM", "KADLEC", "ECCLESI", "COMPLEC", "COMPLEJ", "ROBLEDO")
+	encodeMacWords     = newWordList("MC", "MACIVER", "MACEWEN", "MACELROY", "MACILROY", "MACINTOSH")
+	testSilentMb1Words = newWordList("DUMB", "BOMB", "DAMN", "LAMB", "NUMB", "TOMB")
+	testMnWords        = newWordList("S", "LY", "ER", "ED", "ING", "EST")
+	encodeRzWords      = newWordList("GARZ", "KURZ", "MARZ", "MERZ", "HERZ", "PERZ", "WARZ")
+	testSilentRWords2  = newWordList(
+		"CROUP", "TORCH", "CLOUT", "FOURN", "GAUTH", "TROTT", "DEROS", "CHART")
+	testSilentRWords3 = newWordList(
+		"CHEVAL", "LAVOIS", "PELLET", "SOMMEL", "TREPAN", "LETELL", "COLOMB")
+	encodeVowelReTranspositionWords = newWordList(
+		"LDRED", "LFRED", "NDRED", "NFRED", "NDRES", "IFRED")
+	namesBeginningWithSwThatGetAltSvWords = newWordList(
+		"SWANSON", "SWENSON", "SWINSON", "SWENSEN", "SWOBODA", "SWIDERSKI", "SWARTHOUT", "SWEARENGIN")
+	namesBeginningWithSwThatGetAlvXVWords = newWordList(
+		"SWART", "SWARTZ", "SWARTS", "SWIGER", "SWITZER", "SWANGER", "SWIGERT", "SWIGART", "SWIHART",
+		"SWEITZER", "SWATZELL", "SWINDLER", "SWINEHART", "SWEARINGEN")
+	encodeSilentFrenchSFinalWords = newWordList(
+		"YVES", "ARKANSAS", "FRANCAIS", "CRUDITES", "BRUYERES", "DESCARTES", "DESCHUTES", "DESCHAMPS",
+		"DESROCHES", "DESCHENES", "RENDEZVOUS", "CONTRETEMPS", "DESLAURIERS")
+	encodeSilentFrenchSInternalWords = newWordList(
+		"MESNES", "DESCHAM", "DESPRES", "DESROCH", "DESROSI", "DESJARD", "DESMARA", "DESCHEN", "DESHOTE",
+		"DESLAUR", "DESCARTES")
+	encodeStlWords = newWordList(
+		"KRISTEN", "KRYSTLE", "CRYSTLE", "KRISTLE", "CHRISTENSEN", "CHRISTENSON")
+	encodeShWords2 = newWordList(
+		"ARMON", "ONEST", "ALLOW", "OLDER", "OPPER", "EIMER", "ANDLE", "ONOUR", "ABILLE", "UMANCE",
+		"ABITUA")
+	encodeSchWords = newWordList(
+		"OO", "ER", "EN", "UY", "ED", "EM", "IA", "IZ", "IS", "OL")
+	encodeSsWords = newWordList(
+		"USSIA", "ESSUR", "ISSUR", "ISSUE", "ESSIAN", "ASSURE", "ASSURA", "ISSUAB", "ISSUAN", "ASSIUS")
+	encodeSiaWords = newWordList(
+		"JAMES", "NICOS", "PEGAS", "PEPYS", "HOBBES", "HOLMES", "JAQUES", "KEYNES", "MALTHUS", "HOMOOUS",
+		"MAGLEMOS", "HOMOIOUS", "LEVALLOIS", "TARDENOIS")
+	encodeTInitialWords      = newWordList("TSO", "TSA", "TSU", "TSAO", "TSAI", "TSING", "TSANG")
+	encodeTInitialWords2     = newWordList("HAI", "HUY", "HAO", "HYME", "HYMY", "HANH", "HERES")
+	encodeSilentFrenchTWords = newWordList(
+		"BERET", "BIDET", "FILET", "DEBUT", "DEPOT", "PINOT", "TAROT")
+	encodeSilentFrenchTWords2 = newWordList(
+		"BALLET", "BUFFET", "CACHET", "CHALET", "ESPRIT", "RAGOUT", "GOULET", "CHABOT", "BENOIT")
+	encodeSilentFrenchTWords3 = newWordList(
+		"GOURMET", "BOUQUET", "CROCHET", "CROQUET", "PARFAIT", "PINCHOT", "CABARET", "PARQUET",
+		"RAPPORT", "TOUCHET", "COURBET", "DIDEROT")
+	encodeSilentFrenchTWords4 = newWordList(
+		"ENTREPOT", "CABERNET", "DUBONNET", "MASSENET", "MUSCADET", "RICOCHET", "ESCARGOT")
+	encodeTurTiuSuffixesWords         = newWordList("URE", "URA", "URI", "URY", "URO", "IUS")
+	encodeThPronouncedSeparatelyWords = newWordList(
+		"HOOD", "HEAD", "HEID", "HAND", "HILL", "HOLD", "HAWK", "HEAP", "HERD", "HOLE", "HOOK", "HUNT",
+		"HUMO", "HAUS", "HOFF", "HARD")
+	encodeThPronouncedSeparatelyWords2 = newWordList(
+		"GOTHAM", "WITHAM", "LATHAM", "BENTHAM", "WALTHAM", "WORTHAM", "GRANTHAM")
+	encodeThWords = newWordList(
+		"OMAS", "OMPS", "OMPK", "OMSO", "OMSE", "AMES", "OVEN", "OFEN", "ILDA", "ILDE")
+	encodeWhWords  = newWordList("OA", "OP", "OOP", "OMP", "ORL", "ORT", "OOSH")
+	encodeWhWords2 = newWordList(
+		"IDE", "ARD", "EAD", "AWK", "ERD", "OOK", "AND", "OLE", "OOD", "EART", "OUSE", "OUND", "AMMER")
+	encodeSkipSilentUeWords = newWordList(
+		"RISQUE", "PIROGUE", "ENRIQUE", "BARBEQUE", "PALENQUE", "APPLIQUE", "COMMUNIQUE")
+	encodeEPronouncedAtEndWords2 = newWordList(
+		"BKE", "DKE", "FKE", "KKE", "LKE", "NKE", "MKE", "PKE", "TKE", "VKE", "ZKE")
+	encodeSilentInternalEWords2 = newWordList(
+		"BLAKE", "BRAKE", "BRINE", "CARLE", "CLEVE", "DUNNE", "HEDGE", "HOUSE", "JEFFE", "LUNCE",
+		"STOKE", "STONE", "THORE", "WEDGE", "WHITE")
+	skipVowelsWords = newWordList(
+		"HOP", "HIDE", "HARD", "HEAD", "HAWK", "HERD", "HOOK", "HAND", "HOLE", "HEART", "HOUSE", "HOUND",
+		"HAMMER")
+)
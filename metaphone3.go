@@ -1,7 +1,9 @@
 package metaphone3
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"unicode"
 )
 
@@ -9,8 +11,14 @@ import (
 var debug = false
 
 // DefaultMaxLength is the max number of runes in a result when not specified in the encoder
+//
+// Deprecated: changing it changes the keys of every Encoder in the program that doesn't
+// set MaxLength.  Set MaxLength on the Encoder, or use FullLength and Key.Truncate.
 var DefaultMaxLength = 8
 
+// DefaultMaxAlternates is the max number of keys returned by EncodeAll when not specified in the encoder
+var DefaultMaxAlternates = 16
+
 // Encoder is a metaphone3 encoder that contains options and state for encoding.  It is not
 // safe to use across goroutines.
 type Encoder struct {
@@ -30,45 +38,188 @@ type Encoder struct {
 	// The max allowed length of the output metaphs, if <= 0 then the DefaultMaxLength is used
 	MaxLength int
 
+	// FullLength ignores MaxLength and returns the metaphs for the whole input.  A full
+	// length Key can be cut down with Key.Truncate to the key for any MaxLength.
+	FullLength bool
+
+	// MaxAlternates limits the number of keys returned by EncodeAll, if <= 0 then
+	// DefaultMaxAlternates is used
+	MaxAlternates int
+
+	// Exceptions holds forced encodings that are checked before the rules run, if nil
+	// then only the rules are used
+	Exceptions *Exceptions
+
+	// InputPolicy controls how runes that aren't letters are handled, the default
+	// InputSkip leaves them for the rules to skip over
+	InputPolicy InputPolicy
+
+	// Stats, if set, counts the inputs encoded and the rules that fired.  Words longer
+	// than the max length are encoded to the end to check if their keys were cut, and
+	// every input takes the lock of the Stats, so encoding is about a fifth slower.
+	Stats *Stats
+
+	// maxLen is the length limit for the current input
+	maxLen int
+
+	// text is the whole upper cased input, in is the word being encoded
+	text               []rune
+	inputErr           error
 	in                 []rune
 	idx                int
 	lastIdx            int
 	primBuf, secondBuf []rune
 	flagAlInversion    bool
+
+	// when trackSegments is set every addition to the outputs is recorded
+	// so that all the pronunciation branches can be rebuilt
+	trackSegments bool
+	segments      []segment
+
+	// a matching supplemental exception for the current input
+	supplement *Exception
+
+	// for the Stats: the keys were cut at maxLen, the runes nothing encoded and the
+	// rules that fired for the current input
+	truncated  bool
+	dropped    int
+	firedRules []string
 }
 
 // Encode takes in a string and returns primary and secondary metaphones.
 // Both will be blank if given a blank input, and secondary can be blank
 // if there's only one metaphone.
+//
+// Encode accepts any string, including invalid UTF-8, and doesn't panic on any input.
+// This is checked by FuzzEncode for every combination of options.
 func (e *Encoder) Encode(in string) (primary, secondary string) {
+	e.trackSegments = false
+	return e.encode(in)
+}
+
+// ErrInternal is wrapped by the errors from TryEncode
+var ErrInternal = errors.New("metaphone3: internal error")
+
+// TryEncode is Encode for callers that can't risk a crash, e.g. servers encoding
+// user input.  Encode doesn't panic on any input, but if a bug in the rules ever
+// makes it panic TryEncode recovers and returns an error wrapping ErrInternal
+// with blank metaphones.  The Encoder can still be used after an error.
+// With InputReject it returns ErrNonLetter for inputs that aren't all letters.
+func (e *Encoder) TryEncode(in string) (primary, secondary string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			primary, secondary = "", ""
+			err = fmt.Errorf("%w: encoding %q: %v", ErrInternal, in, r)
+		}
+	}()
+
+	primary, secondary = e.Encode(in)
+	if e.inputErr != nil {
+		return "", "", fmt.Errorf("%w: %q", e.inputErr, in)
+	}
+	return primary, secondary, nil
+}
+
+func (e *Encoder) encode(in string) (primary, secondary string) {
+	e.segments = e.segments[:0]
+	e.supplement = nil
+	e.inputErr = nil
+	if e.Stats != nil {
+		e.truncated, e.dropped, e.firedRules = false, 0, e.firedRules[:0]
+		defer func() { e.Stats.record(e, primary, secondary) }()
+	}
 	if in == "" {
 		return "", ""
 	}
 
-	if e.MaxLength <= 0 {
-		e.MaxLength = DefaultMaxLength
+	// setup our input buffer, to-upper everything and apply the input policy
+	if e.inputErr = e.prepareInput(in); e.inputErr != nil {
+		return "", ""
 	}
 
-	e.flagAlInversion = false
+	// the options are only read, so an Encoder's keys never depend on earlier calls
+	bufCap := e.MaxLength
+	switch {
+	case e.FullLength:
+		e.maxLen = math.MaxInt
+		bufCap = 2 * len(e.text)
+	case e.MaxLength <= 0:
+		e.maxLen = DefaultMaxLength
+		bufCap = e.maxLen
+	default:
+		e.maxLen = e.MaxLength
+	}
+
+	e.primBuf = primeBuf(e.primBuf, bufCap)
+	e.secondBuf = primeBuf(e.secondBuf, bufCap)
+
+	if e.InputPolicy == InputSeparate || e.InputPolicy == InputDigits {
+		words := 0
+		for start := 0; start < len(e.text); {
+			end := start
+			for end < len(e.text) && e.text[end] != ' ' {
+				end++
+			}
+			e.encodeWord(e.text[start:end])
+			words++
+			start = end + 1
+		}
+		// a supplement is an alternate for a whole input, not for one word of several
+		if words > 1 {
+			e.supplement = nil
+		}
+	} else {
+		e.encodeWord(e.text)
+	}
 
-	// setup our input buffer and to-upper everything
-	e.in = make([]rune, 0, len(in))
-	for _, r := range in {
-		e.in = append(e.in, unicode.ToUpper(r))
+	// trim our buffers if needed
+	if len(e.primBuf) > e.maxLen || len(e.secondBuf) > e.maxLen {
+		e.truncated = true
+	}
+	if len(e.primBuf) > e.maxLen {
+		e.primBuf = e.primBuf[:e.maxLen]
 	}
+	if len(e.secondBuf) > e.maxLen {
+		e.secondBuf = e.secondBuf[:e.maxLen]
+	}
+
+	// after the trim, keys that only differ past the max length have no secondary
+	e.applySupplement()
+
+	if areEqual(e.primBuf, e.secondBuf) {
+		return string(e.primBuf), ""
+	}
+
+	return string(e.primBuf), string(e.secondBuf)
+}
+
+// encodeWord runs the rules over one word, adding to the outputs
+func (e *Encoder) encodeWord(word []rune) {
+	e.in = word
 	e.lastIdx = len(e.in) - 1
+	e.flagAlInversion = false
+
+	// an exception can take over some or all of the input
+	start := e.applyException()
 
-	e.primBuf = primeBuf(e.primBuf, e.MaxLength)
-	e.secondBuf = primeBuf(e.secondBuf, e.MaxLength)
+	// with Stats the rest of the word is encoded after the buffers are full to see if
+	// the cut loses anything, then thrown away so the keys are the same as without Stats
+	cut := false
+	var cutPrim, cutSec, cutRules, cutDropped int
 
 	// lets go rune-by-rune through the input string
-	for e.idx = 0; e.idx < len(e.in); e.idx++ {
+	for e.idx = start; e.idx < len(e.in); e.idx++ {
 
 		// double check our output buffers, if they're full then we're done
 		// we're not checking exact "=" just be compat with the reference java implementation
-		// that means our buffers could be longer than MaxLength by a bit
-		if len(e.primBuf) >= e.MaxLength && len(e.secondBuf) >= e.MaxLength {
-			break
+		// that means our buffers could be longer than MaxLength by a bit.
+		// When tracking segments we keep going since other branches may still be short.
+		if !cut && !e.trackSegments && len(e.primBuf) >= e.maxLen && len(e.secondBuf) >= e.maxLen {
+			if e.Stats == nil {
+				break
+			}
+			cut = true
+			cutPrim, cutSec, cutRules, cutDropped = len(e.primBuf), len(e.secondBuf), len(e.firedRules), e.dropped
 		}
 
 		if debug {
@@ -77,49 +228,49 @@ func (e *Encoder) Encode(in string) (primary, secondary string) {
 
 		switch c := e.in[e.idx]; c {
 		case 'B':
-			e.encodeB()
+			e.applyRules(rulesB)
 		case 'ß', 'Ç':
 			e.metaphAdd('S')
 		case 'C':
-			e.encodeC()
+			e.applyRules(rulesC)
 		case 'D':
-			e.encodeD()
+			e.applyRules(rulesD)
 		case 'F':
-			e.encodeF()
+			e.applyRules(rulesF)
 		case 'G':
-			e.encodeG()
+			e.applyRules(rulesG)
 		case 'H':
-			e.encodeH()
+			e.applyRules(rulesH)
 		case 'J':
-			e.encodeJ()
+			e.applyRules(rulesJ)
 		case 'K':
-			e.encodeK()
+			e.applyRules(rulesK)
 		case 'L':
-			e.encodeL()
+			e.applyRules(rulesL)
 		case 'M':
-			e.encodeM()
+			e.applyRules(rulesM)
 		case 'N':
-			e.encodeN()
+			e.applyRules(rulesN)
 		case 'Ñ':
 			e.metaphAdd('N')
 		case 'P':
-			e.encodeP()
+			e.applyRules(rulesP)
 		case 'Q':
-			e.encodeQ()
+			e.applyRules(rulesQ)
 		case 'R':
-			e.encodeR()
+			e.applyRules(rulesR)
 		case 'S':
-			e.encodeS()
+			e.applyRules(rulesS)
 		case 'T':
-			e.encodeT()
+			e.applyRules(rulesT)
 		case 'Ð', 'Þ':
 			e.metaphAdd('0')
 		case 'V':
-			e.encodeV()
+			e.applyRules(rulesV)
 		case 'W':
-			e.encodeW()
+			e.applyRules(rulesW)
 		case 'X':
-			e.encodeX()
+			e.applyRules(rulesX)
 		case '\uC28A':
 			//wat?
 			e.metaphAdd('X')
@@ -127,76 +278,47 @@ func (e *Encoder) Encode(in string) (primary, secondary string) {
 			//wat?
 			e.metaphAdd('S')
 		case 'Z':
-			e.encodeZ()
+			e.applyRules(rulesZ)
 		default:
 			if isVowel(c) {
 				e.encodeVowels()
+			} else {
+				e.dropped++
 			}
 		}
 	}
 
-	// trim our buffers if needed
-	if len(e.primBuf) > e.MaxLength {
-		e.primBuf = e.primBuf[:e.MaxLength]
-	}
-	if len(e.secondBuf) > e.MaxLength {
-		e.secondBuf = e.secondBuf[:e.MaxLength]
-	}
-
-	if areEqual(e.primBuf, e.secondBuf) {
-		return string(e.primBuf), ""
+	if cut {
+		if len(e.primBuf) > cutPrim || len(e.secondBuf) > cutSec {
+			e.truncated = true
+		}
+		e.primBuf, e.secondBuf = e.primBuf[:cutPrim], e.secondBuf[:cutSec]
+		e.firedRules, e.dropped = e.firedRules[:cutRules], cutDropped
 	}
-
-	return string(e.primBuf), string(e.secondBuf)
 }
 
 //////////////////////////////////////////////////////////////////////////////////////////////////////
 // Detailed encoder functions
 //////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func (e *Encoder) encodeB() {
-	if e.encodeSilentB() {
-		return
-	}
-
-	// "-mb", e.g", "dumb", already skipped over under
-	// 'M', altho it should really be handled here...
-	e.metaphAddExactApprox("B", "P")
-
-	// skip double B, or BPx where X isn't H
-	if e.charNextIs('B') ||
-		(e.charNextIs('P') && e.idx+2 < len(e.in) && e.in[e.idx+2] != 'H') {
-		e.idx++
-	}
-}
-
-// Encodes silent 'B' for cases not covered under "-mb-"
-func (e *Encoder) encodeSilentB() bool {
-	//'debt', 'doubt', 'subtle'
-	if e.stringAt(-2, "DEBT", "SUBTL", "SUBTIL") || e.stringAt(-3, "DOUBT") {
-		e.metaphAdd('T')
-		e.idx++
-		return true
-	}
-
-	return false
-}
-
-func (e *Encoder) encodeC() {
-	if e.encodeSilentCAtBeginning() ||
-		e.encodeCaToS() ||
-		e.encodeCoToS() ||
-		e.encodeCh() ||
-		e.encodeCcia() ||
-		e.encodeCc() ||
-		e.encodeCkCgCq() ||
-		e.encodeCFrontVowel() ||
-		e.encodeSilentC() ||
-		e.encodeCz() ||
-		e.encodeCs() {
-		return
-	}
-
+// rulesC are tried in order for a 'C', the first one that matches encodes it
+var rulesC = []rule{
+	{name: "encodeSilentCAtBeginning", do: (*Encoder).encodeSilentCAtBeginning},
+	{name: "encodeCaToS", do: (*Encoder).encodeCaToS},
+	{name: "encodeCoToS", do: (*Encoder).encodeCoToS},
+	{when: at(0, "CH"), then: rulesCh},
+	{name: "encodeCcia", do: (*Encoder).encodeCcia},
+	{name: "encodeCc", do: (*Encoder).encodeCc},
+	{name: "encodeCkCgCq", do: (*Encoder).encodeCkCgCq},
+	{name: "encodeCFrontVowel", do: (*Encoder).encodeCFrontVowel},
+	{name: "encodeSilentC", do: (*Encoder).encodeSilentC},
+	{name: "encodeCz", do: (*Encoder).encodeCz},
+	{name: "encodeCs", do: (*Encoder).encodeCs},
+	{name: "encodeC", do: (*Encoder).encodeC},
+}
+
+// encodeC is the general handling of a 'C' when none of the special cases match
+func (e *Encoder) encodeC() bool {
 	if !e.stringAt(-1, "C", "K", "G", "Q") {
 		e.metaphAdd('K')
 	}
@@ -213,6 +335,7 @@ func (e *Encoder) encodeC() {
 			}
 		}
 	}
+	return true
 }
 
 func (e *Encoder) encodeSilentCAtBeginning() bool {
@@ -228,7 +351,7 @@ func (e *Encoder) encodeCaToS() bool {
 	// Special case: 'caesar'.
 	// Also, where cedilla not used, as in "linguica" => LNKS
 	if (e.idx == 0 && e.stringAt(0, "CAES", "CAEC", "CAEM")) ||
-		e.stringStart("FACADE", "FRANCAIS", "FRANCAIX", "LINGUICA", "GONCALVES", "PROVENCAL") {
+		e.listStart(encodeCaToSWords) {
 		e.metaphAdd('S')
 		e.advanceCounter(1, 0)
 		return true
@@ -243,7 +366,6 @@ func (e *Encoder) encodeCoToS() bool {
 	// e.g. 'coelecanth' => SLKN0
 	if e.stringAt(0, "COEL") && (e.isVowelAt(4) || e.idx+3 == e.lastIdx) ||
 		e.stringAt(0, "COENA", "COENO") || e.stringStart("GARCON", "FRANCOIS", "MELANCON") {
-
 		e.metaphAdd('S')
 		e.advanceCounter(2, 0)
 		return true
@@ -252,23 +374,21 @@ func (e *Encoder) encodeCoToS() bool {
 	return false
 }
 
-func (e *Encoder) encodeCh() bool {
-	if !e.stringAt(0, "CH") {
-		return false
-	}
-
-	if e.encodeChae() ||
-		e.encodeChToH() ||
-		e.encodeSilentCh() ||
-		e.encodeArch() ||
-		e.encodeChToX() ||
-		e.encodeEnglishChToK() ||
-		e.encodeGermanicChToK() ||
-		e.encodeGreekChInitial() ||
-		e.encodeGreekChNonInitial() {
-		return true
-	}
+// rulesCh are tried in order for a "CH"
+var rulesCh = []rule{
+	{name: "encodeChae", do: (*Encoder).encodeChae},
+	{name: "encodeChToH", do: (*Encoder).encodeChToH},
+	{name: "encodeSilentCh", do: (*Encoder).encodeSilentCh},
+	{name: "encodeArch", do: (*Encoder).encodeArch},
+	{name: "encodeChToX", do: (*Encoder).encodeChToX},
+	{name: "encodeEnglishChToK", do: (*Encoder).encodeEnglishChToK},
+	{name: "encodeGermanicChToK", do: (*Encoder).encodeGermanicChToK},
+	{name: "encodeGreekChInitial", do: (*Encoder).encodeGreekChInitial},
+	{name: "encodeGreekChNonInitial", do: (*Encoder).encodeGreekChNonInitial},
+	{name: "encodeCh", do: (*Encoder).encodeCh},
+}
 
+func (e *Encoder) encodeCh() bool {
 	if e.idx > 0 {
 		if e.stringStart("MC") && e.idx == 1 {
 			//e.g., "McHugh"
@@ -307,9 +427,7 @@ func (e *Encoder) encodeChae() bool {
 func (e *Encoder) encodeChToH() bool {
 	// hebrew => 'H', e.g. 'channukah', 'chabad'
 	if (e.idx == 0 &&
-		(e.stringAt(2, "AIM", "ETH", "ELM", "ASID", "AZAN",
-			"UPPAH", "UTZPA", "ALLAH", "ALUTZ", "AMETZ",
-			"ESHVAN", "ADARIM", "ANUKAH", "ALLLOTH", "ANNUKAH", "AROSETH"))) ||
+		(e.listAt(2, encodeChToHWords))) ||
 		e.stringAt(-3, "CLACHAN") {
 
 		e.metaphAdd('H')
@@ -333,7 +451,7 @@ func (e *Encoder) encodeSilentCh() bool {
 
 func (e *Encoder) encodeChToX() bool {
 	// e.g. 'approach', 'beach'
-	if (e.stringAt(-2, "OACH", "EACH", "EECH", "OUCH", "OOCH", "MUCH", "SUCH") && !e.stringAt(-3, "JOACH")) ||
+	if (e.listAt(-2, encodeChToXWords) && !e.stringAt(-3, "JOACH")) ||
 		e.stringAtEnd(-1, "ACHA", "ACHO") || // e.g. 'dacha', 'macho'
 		e.stringAtEnd(0, "CHOT", "CHOD", "CHAT") ||
 		(e.stringAtEnd(-1, "OCHE") && !e.stringAt(-2, "DOCHE")) ||
@@ -355,7 +473,7 @@ func (e *Encoder) encodeEnglishChToK() bool {
 	//'ache', 'echo', alternate spelling of 'michael'
 	if (e.idx == 1 && rootOrInflections(e.in, "ACHE")) ||
 		((e.idx > 3 && rootOrInflections(e.in[e.idx-1:], "ACHE")) &&
-			e.stringStart("EAR", "HEAD", "BACK", "HEART", "BELLY", "TOOTH")) ||
+			e.listStart(encodeEnglishChToKWords)) ||
 		e.stringAt(-1, "ECHO") ||
 		e.stringAt(-2, "MICHEAL") ||
 		e.stringAt(-4, "JERICHO") ||
@@ -395,10 +513,10 @@ func (e *Encoder) encodeGermanicChToK() bool {
 			!e.stringAtEnd(-5, "ALDRICH") &&
 			!e.stringAtEnd(-6, "GOODRICH") &&
 			!e.stringAtEnd(-7, "GINGERICH"))) ||
-		e.stringAtEnd(-4, "ULRICH", "LFRICH", "LLRICH", "EMRICH", "ZURICH", "EYRICH") ||
+		e.listAtEnd(-4, encodeGermanicChToKWords) ||
 		// e.g., 'wachtler', 'wechsler', but not 'tichner'
 		((e.stringAt(-1, "A", "O", "U", "E") || e.idx == 0) &&
-			e.stringAt(2, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ")) {
+			e.listAt(2, encodeGermanicChToKWords2)) {
 
 		// "CHR/L-" e.g. 'chris' do not get
 		// alt pronunciation of 'X'
@@ -421,15 +539,15 @@ func (e *Encoder) encodeArch() bool {
 		// "-ARCH-" has many combining forms where "-CH-" => K because of its
 		// derivation from the greek
 		if ((e.isVowelAt(2) && e.stringAt(-2, "ARCHA", "ARCHI", "ARCHO", "ARCHU", "ARCHY")) ||
-			e.stringAt(-2, "ARCHEA", "ARCHEG", "ARCHEO", "ARCHET", "ARCHEL", "ARCHES", "ARCHEP", "ARCHEM", "ARCHEN") ||
+			e.listAt(-2, encodeArchWords) ||
 			e.stringAtEnd(-2, "ARCH") ||
 			e.stringStart("MENARCH")) &&
 			(!rootOrInflections(e.in, "ARCH") &&
 				!e.stringAt(-4, "SEARCH", "POARCH") &&
-				!e.stringStart("ARCHER", "ARCHIE", "ARCHENEMY", "ARCHIBALD", "ARCHULETA", "ARCHAMBAU") &&
+				!e.listStart(encodeArchWords2) &&
 				!((((e.stringAt(-3, "LARCH", "MARCH", "PARCH") ||
 					e.stringAt(-4, "STARCH")) &&
-					!e.stringStart("EPARCH", "NOMARCH", "EXILARCH", "HIPPARCH", "MARCHESE", "ARISTARCH", "MARCHETTI")) ||
+					!e.listStart(encodeArchWords3)) ||
 					rootOrInflections(e.in, "STARCH")) &&
 					(!e.stringAt(-2, "ARCHU", "ARCHY") || e.stringStart("STARCHY")))) {
 
@@ -446,16 +564,14 @@ func (e *Encoder) encodeArch() bool {
 
 func (e *Encoder) encodeGreekChInitial() bool {
 	// greek roots e.g. 'chemistry', 'chorus', ch at beginning of root
-	if (e.stringAt(0, "CHAMOM", "CHARAC", "CHARIS", "CHARTO", "CHARTU", "CHARYB", "CHRIST", "CHEMIC", "CHILIA") ||
-		(e.stringAt(0, "CHEMI", "CHEMO", "CHEMU", "CHEMY", "CHOND", "CHONA", "CHONI", "CHOIR", "CHASM",
-			"CHARO", "CHROM", "CHROI", "CHAMA", "CHALC", "CHALD", "CHAET", "CHIRO", "CHILO", "CHELA", "CHOUS",
-			"CHEIL", "CHEIR", "CHEIM", "CHITI", "CHEOP") && !(e.stringAt(0, "CHEMIN") || e.stringAt(-2, "ANCHONDO"))) ||
+	if (e.listAt(0, encodeGreekChInitialWords2) ||
+		(e.listAt(0, encodeGreekChInitialWords) && !(e.stringAt(0, "CHEMIN") || e.stringAt(-2, "ANCHONDO"))) ||
 		(e.stringAt(0, "CHISM", "CHELI") &&
 			// exclude spanish "machismo"
 			!(e.stringStart("MICHEL", "MACHISMO", "RICHELIEU", "REVANCHISM") ||
 				e.stringExact("CHISM"))) ||
 		// include e.g. "chorus", "chyme", "chaos"
-		(e.stringAt(0, "CHOR", "CHOL", "CHYM", "CHYL", "CHLO", "CHOS", "CHUS", "CHOE") && !e.stringStart("CHOLLO", "CHOLLA", "CHORIZ")) ||
+		(e.listAt(0, encodeGreekChInitialWords3) && !e.stringStart("CHOLLO", "CHOLLA", "CHORIZ")) ||
 		// "chaos" => K but not "chao"
 		(e.stringAt(0, "CHAO") && e.idx+3 != e.lastIdx) ||
 		// e.g. "abranchiate"
@@ -475,7 +591,8 @@ func (e *Encoder) encodeGreekChInitial() bool {
 		if e.stringAt(2, "R", "L") {
 			e.metaphAdd('K')
 		} else {
-			e.metaphAddAlt('K', 'X')
+			// greek roots rarely get the english 'X'
+			e.metaphAddAltWeight('K', 'X', altUnlikely)
 		}
 		e.idx++
 		return true
@@ -486,22 +603,15 @@ func (e *Encoder) encodeGreekChInitial() bool {
 
 func (e *Encoder) encodeGreekChNonInitial() bool {
 	//greek & other roots e.g. 'tachometer', 'orchid', ch in middle or end of root
-	if e.stringAt(-2, "LYCHN", "TACHO", "ORCHO", "ORCHI", "LICHO", "ORCHID", "NICHOL",
-		"MECHAN", "LICHEN", "MACHIC", "PACHEL", "RACHIF", "RACHID",
-		"RACHIS", "RACHIC", "MICHAL", "ORCHESTR") ||
-		e.stringAt(-3, "MELCH", "GLOCH", "TRACH", "TROCH", "BRACH", "SYNCH", "PSYCH",
-			"STICH", "PULCH", "EPOCH") ||
+	if e.listAt(-2, encodeGreekChNonInitialWords1) ||
+		e.listAt(-3, encodeGreekChNonInitialWords3) ||
 		(e.stringAt(-3, "TRICH") && !e.stringAt(-5, "OSTRICH")) ||
-		(e.stringAt(-2, "TYCH", "TOCH", "BUCH", "MOCH", "CICH", "DICH", "NUCH", "EICH", "LOCH",
-			"DOCH", "ZECH", "WYCH") && !(e.stringAt(-4, "INDOCHINA") || e.stringAt(-2, "BUCHON"))) ||
+		(e.listAt(-2, encodeGreekChNonInitialWords4) && !(e.stringAt(-4, "INDOCHINA") || e.stringAt(-2, "BUCHON"))) ||
 		((e.idx == 1 || e.idx == 2) && e.stringAt(-1, "OCHER", "ECHIN", "ECHID")) ||
-		e.stringAt(-4, "BRONCH", "STOICH", "STRYCH", "TELECH", "PLANCH", "CATECH", "MANICH", "MALACH",
-			"BIANCH", "DIDACH", "BRANCHIO", "BRANCHIF") ||
+		e.listAt(-4, encodeGreekChNonInitialWords5) ||
 		e.stringStart("ICHA", "ICHN") ||
 		(e.stringAt(-1, "ACHAB", "ACHAD", "ACHAN", "ACHAZ") && !e.stringAt(-2, "MACHADO", "LACHANC")) ||
-		e.stringAt(-1, "ACHISH", "ACHILL", "ACHAIA", "ACHENE", "ACHAIAN", "ACHATES", "ACHIRAL", "ACHERON",
-			"ACHILLEA", "ACHIMAAS", "ACHILARY", "ACHELOUS", "ACHENIAL", "ACHERNAR",
-			"ACHALASIA", "ACHILLEAN", "ACHIMENES", "ACHIMELECH", "ACHITOPHEL") ||
+		e.listAt(-1, encodeGreekChNonInitialWords2) ||
 		// e.g. 'inchoate'
 		(e.idx == 2 && (e.stringStart("INCHOA")) ||
 			// e.g. 'ischemia'
@@ -567,7 +677,6 @@ func (e *Encoder) encodeCc() bool {
 
 func (e *Encoder) encodeCkCgCq() bool {
 	if e.stringAt(0, "CK", "CG", "CQ") {
-
 		// eastern european spelling e.g. 'gorecki' == 'goresky'
 		if e.stringAtEnd(0, "CKI", "CKY") && len(e.in) > 6 {
 			e.metaphAddStr("K", "SK")
@@ -594,7 +703,6 @@ func (e *Encoder) encodeCFrontVowel() bool {
 			e.encodeCe() ||
 			e.encodeCi() ||
 			e.encodeLatinateSuffixes() {
-
 			e.advanceCounter(1, 0)
 			return true
 		}
@@ -610,7 +718,7 @@ func (e *Encoder) encodeCFrontVowel() bool {
 func (e *Encoder) encodeBritishSilentCE() bool {
 	// english place names like e.g.'gloucester' pronounced glo-ster
 	if e.stringAtEnd(1, "ESTER") || e.stringAt(1, "ESTERSHIRE") {
-		return true
+		return e.fired("encodeBritishSilentCE")
 	}
 
 	return false
@@ -626,7 +734,7 @@ func (e *Encoder) encodeCe() bool {
 		e.stringAtEnd(1, "ELLO") { // e.g. cello
 
 		e.metaphAddAlt('X', 'S')
-		return true
+		return e.fired("encodeCe")
 	}
 
 	return false
@@ -642,27 +750,27 @@ func (e *Encoder) encodeCi() bool {
 		e.stringAt(-3, "MARCIA") || // special cases
 		e.stringAt(-2, "ANCIENT") {
 		e.metaphAddAlt('X', 'S')
-		return true
+		return e.fired("encodeCi")
 	}
 
 	// exception
 	if e.stringAt(-4, "COERCION") {
 		e.metaphAdd('J')
-		return true
+		return e.fired("encodeCi")
 	}
 
 	// with vowel before C (or at beginning?)
 	if (e.stringAt(0, "CIO", "CIE", "CIA") && e.isVowelAt(-1)) ||
 		e.stringAt(1, "IAO") {
 
-		if (e.stringAt(0, "CIAN", "CIAL", "CIAO", "CIES", "CIOL", "CION") ||
+		if (e.listAt(0, encodeCiWords) ||
 			e.stringAt(-3, "GLACIER") || // exception - "glacier" => 'X' but "spacier" = > 'S'
-			e.stringAt(0, "CIENT", "CIENC", "CIOUS", "CIATE", "CIATI", "CIATO", "CIABL", "CIARY") ||
+			e.listAt(0, encodeCiWords2) ||
 			e.stringAtEnd(0, "CIA", "CIO", "CIAS", "CIOS")) &&
 			!(e.stringAt(-4, "ASSOCIATION") || e.stringStart("OCIE") ||
 				// exceptions mostly because these names are usually from
 				// the spanish rather than the italian in america
-				e.stringAt(-2, "LUCIO", "SOCIO", "SOCIE", "MACIAS", "LUCIANO", "HACIENDA") ||
+				e.listAt(-2, encodeCiWords3) ||
 				e.stringAt(-3, "GRACIE", "GRACIA", "MARCIANO") ||
 				e.stringAt(-4, "PALACIO", "POLICIES", "FELICIANO") ||
 				e.stringAt(-5, "MAURICIO") ||
@@ -674,7 +782,7 @@ func (e *Encoder) encodeCi() bool {
 			e.metaphAddAlt('S', 'X')
 		}
 
-		return true
+		return e.fired("encodeCi")
 	}
 
 	return false
@@ -683,7 +791,7 @@ func (e *Encoder) encodeCi() bool {
 func (e *Encoder) encodeLatinateSuffixes() bool {
 	if e.stringAt(1, "EOUS", "IOUS") {
 		e.metaphAddAlt('X', 'S')
-		return true
+		return e.fired("encodeLatinateSuffixes")
 	}
 	return false
 }
@@ -733,163 +841,47 @@ func (e *Encoder) encodeCs() bool {
 	return false
 }
 
-func (e *Encoder) encodeD() {
-	if e.encodeDg() || e.encodeDj() || e.encodeDtDd() ||
-		e.encodeDToJ() || e.encodeDous() || e.encodeSilentD() {
-		return
-	}
-
-	if e.EncodeExact {
-		// "final de-voicing" in this case
-		// e.g. 'missed' == 'mist'
-		if e.stringAtEnd(-3, "SSED") {
-			e.metaphAdd('T')
-		} else {
-			e.metaphAdd('D')
-		}
-	} else {
-		e.metaphAdd('T')
-	}
-}
-
-func (e *Encoder) encodeDg() bool {
-	if e.stringAt(0, "DG") {
-		// excludes exceptions e.g. 'edgar',
-		// or cases where 'g' is first letter of combining form
-		// e.g. 'handgun', 'waldglas'
-		if e.stringAt(2, "A", "O") ||
-			// e.g. "midgut"
-			// e.g. "handgrip"
-			// e.g. "mudgard"
-			// e.g. "woodgrouse"
-			e.stringAt(1, "GUN", "GUT", "GEAR", "GLAS", "GRIP", "GREN", "GILL", "GRAF",
-				"GUARD", "GUILT", "GRAVE", "GRASS", "GROUSE") {
-
-			e.metaphAddExactApprox("DG", "TK")
-		} else {
-			// e.g. "edge", "abridgment"
-			e.metaphAdd('J')
-		}
-
-		e.idx++
-		return true
-	}
-
-	return false
-}
-
-func (e *Encoder) encodeDj() bool {
-	// e.g. "adjacent"
-	if e.stringAt(0, "DJ") {
-		e.metaphAdd('J')
-		e.idx++
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeDtDd() bool {
-	// eat redundant 'T' or 'D'
-	if e.stringAt(0, "DT", "DD") {
-		if e.stringAt(0, "DTH") {
-			e.metaphAddExactApprox("D0", "T0")
-			e.idx += 2
-		} else {
-			if e.EncodeExact {
-				// devoice it
-				if e.stringAt(0, "DT") {
-					e.metaphAdd('T')
-				} else {
-					e.metaphAdd('D')
-				}
-			} else {
-				e.metaphAdd('T')
-			}
-			e.idx++
-		}
-
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeDToJ() bool {
-	// e.g. "module", "adulate"
-	if (e.stringAt(0, "DUL") && e.isVowelAt(-1) && e.isVowelAt(3)) ||
-		// e.g. "soldier", "grandeur", "procedure"
-		e.stringAtEnd(-1, "LDIER", "NDEUR", "EDURE", "RDURE") ||
-		e.stringAt(-3, "CORDIAL") ||
-		// e.g. "pendulum", "education"
-		// e.g. "individual", "individual", "residuum"
-		e.stringAt(-1, "ADUA", "IDUA", "IDUU", "NDULA", "NDULU", "EDUCA") {
-
-		e.metaphAddExactApproxAlt("J", "D", "J", "T")
-		e.advanceCounter(1, 0)
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeDous() bool {
-	// e.g. "assiduous", "arduous"
-	if e.stringAt(1, "UOUS") {
-		e.metaphAddExactApproxAlt("J", "D", "J", "T")
-		e.advanceCounter(3, 0)
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeSilentD() bool {
-	// silent 'D' e.g. 'wednesday', 'handsome'
-	return e.stringAt(-2, "WEDNESDAY") ||
-		e.stringAt(-3, "HANDKER", "HANDSOM", "WINDSOR") ||
-		// french silent D at end in words or names familiar to americans
-		e.stringEnd("PERNOD", "ARTAUD", "RENAUD", "RIMBAUD", "MICHAUD", "BICHAUD")
-}
-
-func (e *Encoder) encodeF() {
-	// Encode cases where "-FT-" => "T" is usually silent
-	// e.g. 'often', 'soften'
-	// This should really be covered under "T"!
-	if e.stringAt(-1, "OFTEN") {
-		e.metaphAddStr("F", "FT")
-		e.idx++
-		return
-	}
-
-	// eat redundant 'F'
-	if e.charNextIs('F') {
-		e.idx++
-	}
-	e.metaphAdd('F')
+// rulesG are tried in order for a 'G', the first one that matches encodes it
+var rulesG = []rule{
+	{name: "encodeSilentGAtBeginning", do: (*Encoder).encodeSilentGAtBeginning},
+	{name: "encodeGg", do: (*Encoder).encodeGg},
+	{name: "encodeGk", do: (*Encoder).encodeGk},
+	{when: at(1, "H"), then: rulesGh},
+	{name: "encodeSilentG", do: (*Encoder).encodeSilentG},
+	{name: "encodeGn", do: (*Encoder).encodeGn},
+	{name: "encodeGl", do: (*Encoder).encodeGl},
+	{name: "encodeInitialGFrontVowel", do: (*Encoder).encodeInitialGFrontVowel},
+	{name: "encodeNger", do: (*Encoder).encodeNger},
+	{name: "encodeGer", do: (*Encoder).encodeGer},
+	{name: "encodeGel", do: (*Encoder).encodeGel},
+	{name: "encodeNonInitialGFrontVowel", do: (*Encoder).encodeNonInitialGFrontVowel},
+	{name: "encodeGaToJ", do: (*Encoder).encodeGaToJ},
+	{name: "encodeG", do: (*Encoder).encodeG},
 }
 
-func (e *Encoder) encodeG() {
-	if e.encodeSilentGAtBeginning() || e.encodeGg() || e.encodeGk() || e.encodeGh() || e.encodeSilentG() ||
-		e.encodeGn() || e.encodeGl() || e.encodeInitialGFrontVowel() || e.encodeNger() || e.encodeGer() ||
-		e.encodeGel() || e.encodeNonInitialGFrontVowel() || e.encodeGaToJ() {
-		return
-	}
-
+// encodeG is the general handling of a 'G' when none of the special cases match
+func (e *Encoder) encodeG() bool {
 	if !e.stringAt(-1, "C", "K", "G", "Q") {
 		e.metaphAddExactApprox("G", "K")
 	}
+	return true
 }
 
 func (e *Encoder) encodeSilentGAtBeginning() bool {
-	return e.stringAtStart(0, "GN")
+	if e.stringAtStart(0, "GN") {
+		return true
+	}
+	return false
 }
 
 func (e *Encoder) encodeGg() bool {
 	if e.charNextIs('G') {
 		// italian e.g, 'loggia', 'caraveggio', also 'suggest' and 'exaggerate'
-		if e.stringAt(-1, "AGGIA", "OGGIA", "AGGIO", "EGGIO", "EGGIA", "IGGIO") ||
+		if e.listAt(-1, encodeGgWords) ||
 			// 'ruggiero' but not 'snuggies'
 			(e.stringAt(-1, "UGGIE") && !(e.idx+3 == e.lastIdx || e.idx+4 == e.lastIdx)) ||
 			e.stringAtEnd(-1, "AGGI", "OGGI") ||
 			e.stringAt(-2, "SUGGES", "XAGGER", "REGGIE") {
-
 			// expection where "-GG-" => KJ
 			if e.stringAt(-2, "SUGGEST") {
 				e.metaphAddExactApprox("G", "K")
@@ -917,18 +909,26 @@ func (e *Encoder) encodeGk() bool {
 	return false
 }
 
-func (e *Encoder) encodeGh() bool {
-	if e.charNextIs('H') {
-		if e.encodeGhAfterConsonant() || e.encodeInitialGh() || e.encodeGhToJ() || e.encodeGhToH() ||
-			e.encodeUght() || e.encodeGhHPartOfOtherWord() || e.encodeSilentGh() || e.encodeGhToF() {
-			return true
-		}
+// rulesGh are tried in order for a "GH"
+var rulesGh = []rule{
+	{name: "encodeGhAfterConsonant", do: (*Encoder).encodeGhAfterConsonant},
+	{name: "encodeInitialGh", do: (*Encoder).encodeInitialGh},
+	{name: "encodeGhToJ", do: (*Encoder).encodeGhToJ},
+	{name: "encodeGhToH", do: (*Encoder).encodeGhToH},
+	{name: "encodeUght", do: (*Encoder).encodeUght},
+	{name: "encodeGhHPartOfOtherWord", do: (*Encoder).encodeGhHPartOfOtherWord},
+	{name: "encodeSilentGh", do: (*Encoder).encodeSilentGh},
+	// the cases covered here would fall under
+	// the GH_To_F rule below otherwise
+	{name: "encodeGhSpecialCases", do: (*Encoder).encodeGhSpecialCases},
+	{name: "encodeGhToF", do: (*Encoder).encodeGhToF},
+	{name: "encodeGh", do: (*Encoder).encodeGh},
+}
 
-		e.metaphAddExactApprox("G", "K")
-		e.idx++
-		return true
-	}
-	return false
+func (e *Encoder) encodeGh() bool {
+	e.metaphAddExactApprox("G", "K")
+	e.idx++
+	return true
 }
 
 func (e *Encoder) encodeGhAfterConsonant() bool {
@@ -1010,7 +1010,7 @@ func (e *Encoder) encodeSilentGh() bool {
 	// Parker's rule (with some further refinements) - e.g., 'hugh'
 	if ((e.stringAt(-2, "B", "H", "D", "G", "L") ||
 		// e.g., 'bough'
-		(e.stringAt(-3, "B", "H", "D", "K", "W", "N", "P", "V") && !e.stringStart("ENOUGH")) ||
+		(e.listAt(-3, encodeSilentGhWords) && !e.stringStart("ENOUGH")) ||
 		// e.g., 'broughton'
 		// 'plough', 'slaugh'
 		e.stringAt(-4, "B", "H", "PL", "SL") ||
@@ -1024,7 +1024,7 @@ func (e *Encoder) encodeSilentGh() bool {
 		(e.stringAt(-3, "VAUGH", "FEIGH", "LEIGH") ||
 			e.stringAt(-2, "HIGH", "TIGH") ||
 			e.idx+1 == e.lastIdx ||
-			(e.stringAtEnd(2, "IE", "EY", "ES", "ER", "ED", "TY") && !e.stringAt(-5, "GALLAGHER")) ||
+			(e.listAtEnd(2, encodeSilentGhWords2) && !e.stringAt(-5, "GALLAGHER")) ||
 			e.stringAtEnd(2, "Y", "ING", "OUT", "ERTY") ||
 			(!e.isVowelAt(2) || e.stringAt(-3, "GAUGH", "GEOGH", "MAUGH") || e.stringAt(-4, "BROUGHAM")))) &&
 		// exceptions where '-g-' pronounced
@@ -1063,21 +1063,16 @@ func (e *Encoder) encodeGhSpecialCases() bool {
 
 	if handled {
 		e.idx++
+		return true
 	}
 
-	return handled
+	return false
 }
 
 func (e *Encoder) encodeGhToF() bool {
-	// the cases covered here would fall under
-	// the GH_To_F rule below otherwise
-	if e.encodeGhSpecialCases() {
-		return true
-	}
-
 	// e.g., 'laugh', 'cough', 'rough', 'tough'
 	if e.idx > 2 && e.charAt(-1, 'U') && e.isVowelAt(-2) &&
-		e.stringAt(-3, "C", "G", "L", "R", "T", "N", "S") &&
+		e.listAt(-3, encodeGhToFWords) &&
 		!e.stringAt(-4, "BREUGHEL", "FLAUGHER") {
 
 		e.metaphAdd('F')
@@ -1116,8 +1111,7 @@ func (e *Encoder) encodeGn() bool {
 					(e.stringAt(2, "AN", "AC", "IA", "UM") && !(e.stringAt(-3, "POIGNANT") || e.stringAt(-2, "COGNAC"))) ||
 					e.stringStart("SPIGNER", "STEGNER") ||
 					e.stringExact("SIGNE") ||
-					e.stringAt(-2, "LIGNI", "LIGNO", "REGNA", "DIGNI", "WEGNE", "TIGNE",
-						"RIGNE", "REGNE", "TIGNO", "SIGNAL", "SIGNIF", "SIGNAT") ||
+					e.listAt(-2, encodeGnWords) ||
 					e.stringAt(-1, "IGNIT")) &&
 				!e.stringAt(-2, "SIGNET", "LIGNEO"))) ||
 			// not e.g. 'cagney', 'magna'
@@ -1165,22 +1159,14 @@ func (e *Encoder) encodeInitialGFrontVowel() bool {
 }
 
 func (e *Encoder) initialGSoft() bool {
-	if (e.stringAt(1, "EL", "EM", "EN", "EO", "ER", "ES", "IA", "IN", "IO", "IP", "IU", "YM", "YN",
-		"YP", "YR", "EE", "IRA", "IRO") &&
+	if (e.listAt(1, initialGSoftWords1) &&
 		// except for smaller set of cases where => K, e.g. "gerber"
-		!e.stringAt(1, "ELD", "ELT", "ERT", "INZ", "ERH", "ITE", "ERD", "ERL", "ERN", "INT",
-			"EES", "EEK", "ELB", "EER", "ERSH", "ERST", "INSB", "INGR", "EROW", "ERKE", "EREN",
-			"ELLER", "ERDIE", "ERBER", "ESUND", "ESNER", "INGKO", "INKGO",
-			"IPPER", "ESELL", "IPSON", "EEZER", "ERSON", "ELMAN",
-			"ESTALT", "ESTAPO", "INGHAM", "ERRITY", "ERRISH", "ESSNER", "ENGLER",
-			"YNAECOL", "YNECOLO", "ENTHNER", "ERAGHTY",
-			"INGERICH", "EOGHEGAN")) ||
+		!e.listAt(1, initialGSoftWords2)) ||
 		(e.isVowelAt(1) &&
 			(e.stringAt(1, "EE ", "EEW") ||
-				(e.stringAt(1, "IGI", "IRA", "IBE", "AOL", "IDE", "IGL") &&
+				(e.listAt(1, initialGSoftWords3) &&
 					!e.stringAt(1, "IDEON")) ||
-				e.stringAt(1, "ILES", "INGI", "ISEL", "IBBER", "IBBET", "IBLET", "IBRAN", "IGOLO", "IRARD", "IGANT",
-					"IRAFFE", "EEWHIZ", "ILLETTE", "IBRALTA") ||
+				e.listAt(1, initialGSoftWords4) ||
 				(e.stringAt(1, "INGER") && !e.stringAt(1, "INGERICH")))) {
 
 		return true
@@ -1203,11 +1189,9 @@ func (e *Encoder) encodeNger() bool {
 
 		if !(rootOrInflections(e.in, "ANGER") || rootOrInflections(e.in, "LINGER") ||
 			rootOrInflections(e.in, "MALINGER") || rootOrInflections(e.in, "FINGER") ||
-			(e.stringAt(-3, "HUNG", "FING", "BUNG", "WING", "RING", "DING", "ZENG", "ZING",
-				"JUNG", "LONG", "PING", "CONG", "MONG", "BANG", "GANG", "HANG", "LANG", "SANG", "SING",
-				"WANG", "ZANG") &&
+			(e.listAt(-3, encodeNgerWords) &&
 				// exceptions to above where 'G' => J
-				!(e.stringAt(-6, "BOULANG", "SLESING", "KISSING", "DERRING", "BARRING", "PHALANGER") ||
+				!(e.listAt(-6, encodeNgerWords2) ||
 					e.stringAt(-8, "SCHLESING") ||
 					e.stringAt(-5, "SALING", "BELANG") ||
 					e.stringAt(-4, "CHANG"))) ||
@@ -1216,9 +1200,8 @@ func (e *Encoder) encodeNger() bool {
 			e.stringAt(0, "GERICH") ||
 			e.stringAt(-2, "ANGERLY", "ANGERBO", "INGERSO") ||
 			e.stringAt(-3, "WENGER", "MUNGER", "SONGER", "KINGER", "LINGERF") ||
-			e.stringAt(-4, "FLINGER", "SLINGER", "STANGER", "STENGER", "KLINGER", "CLINGER") ||
+			e.listAt(-4, encodeNgerWords3) ||
 			e.stringAt(-5, "SPRINGER", "SPRENGER")) {
-
 			e.metaphAddExactApproxAlt("J", "G", "J", "K")
 		} else {
 			e.metaphAddExactApproxAlt("G", "J", "K", "J")
@@ -1236,16 +1219,12 @@ func (e *Encoder) encodeGer() bool {
 		// e.g. "JAGER", "TIGER", "LIGER", "LAGER", "LUGER", "AUGER", "EAGER", "HAGER",
 		// "SAGER"
 		if ((e.idx == 2 && e.isVowelAt(-1) && !e.isVowelAt(-2) &&
-			!e.stringAt(-2, "PAGER", "WAGER", "NIGER", "ROGER", "LEGER", "CAGER") ||
+			!e.listAt(-2, encodeGerWords2) ||
 			e.stringAt(-2, "AUGER", "EAGER", "INGER", "YAGER")) ||
-			e.stringAt(-3, "SEEGER", "JAEGER", "GEIGER", "KRUGER", "SAUGER", "BURGER",
-				"MEAGER", "MARGER", "RIEGER", "YAEGER", "STEGER", "PRAGER", "SWIGER", "YERGER", "TORGER",
-				"FERGER", "HILGER", "ZEIGER", "YARGER", "COWGER", "CREGER", "KROGER", "KREGER", "GRAGER",
-				"STIGER", "BERGER") ||
+			e.listAt(-3, encodeGerWords) ||
 			// 'berger' but not 'bergerac'
 			e.stringAtEnd(-3, "BERGER") ||
-			e.stringAt(-4, "KREIGER", "KRUEGER", "METZGER", "KRIEGER", "KROEGER", "STEIGER",
-				"DRAEGER", "BUERGER", "BOERGER", "FIBIGER") ||
+			e.listAt(-4, encodeGerWords3) ||
 			// e.g. 'harshbarger', 'winebarger'
 			(e.stringAt(-3, "BARGER") && e.idx > 4) ||
 			// e.g. 'weisgerber'
@@ -1256,7 +1235,6 @@ func (e *Encoder) encodeGer() bool {
 			e.stringAt(-1, "YGERNE") ||
 			e.stringAt(-6, "SCHWEIGER")) &&
 			!(e.stringAt(-5, "BELLIGEREN") || e.stringStart("MARGERY") || e.stringAt(-3, "BERGERAC")) {
-
 			if e.isSlavoGermanic() {
 				e.metaphAddExactApprox("G", "K")
 			} else {
@@ -1280,9 +1258,8 @@ func (e *Encoder) encodeGel() bool {
 		if (len(e.in) == 5 && e.isVowelAt(-1) && !e.isVowelAt(-2) && !e.stringAt(-2, "NIGEL", "RIGEL")) ||
 			// or the following as combining forms
 			e.stringAt(-2, "ENGEL", "HEGEL", "NAGEL", "VOGEL") ||
-			e.stringAt(-3, "MANGEL", "WEIGEL", "FLUGEL", "RANGEL", "HAUGEN", "RIEGEL", "VOEGEL") ||
+			e.listAt(-3, encodeGelWords) ||
 			e.stringAt(-4, "SPEIGEL", "STEIGEL", "WRANGEL", "SPIEGEL", "DANEGELD") {
-
 			if e.isSlavoGermanic() {
 				e.metaphAddExactApprox("G", "K")
 			} else {
@@ -1308,8 +1285,7 @@ func (e *Encoder) encodeNonInitialGFrontVowel() bool {
 		// almost always 'j 'sound
 		if e.stringAtEnd(0, "GE") {
 			// german names with hard g using GE at end
-			if e.stringStart("INGE", "LAGE", "HAGE", "LANGE", "SYNGE", "BENGE", "RUNGE", "HELGE",
-				"BYRGE", "BIRGE", "BERGE", "HAUGE", "RENEGE", "STONGE", "STANGE", "PRANGE", "KRESGE") {
+			if e.listStart(encodeNonInitialGFrontVowelWords) {
 				if e.isSlavoGermanic() {
 					e.metaphAddExactApprox("G", "K")
 				} else {
@@ -1356,7 +1332,7 @@ func (e *Encoder) internalHardNg() bool {
 	if (e.stringAt(-3, "DANG", "FANG", "SING") && !e.stringAt(-5, "DISINGEN")) ||
 		e.stringStart("INGEB", "ENGEB") ||
 		(e.stringAt(-3, "RING", "WING", "HANG", "LONG") &&
-			!(e.stringAt(-4, "CRING", "FRING", "ORANG", "TWING", "CHANG", "PHANG") ||
+			!(e.listAt(-4, internalHardNgWords) ||
 				e.stringAt(-5, "SYRING") ||
 				e.stringAt(-3, "RINGENC", "RINGENT", "LONGITU", "LONGEVI") ||
 				// e.g. 'longino', 'mastrangelo'
@@ -1371,16 +1347,14 @@ func (e *Encoder) internalHardNg() bool {
 }
 
 func (e *Encoder) internalHardGenGinGetGit() bool {
-	if (e.stringAt(-3, "FORGET", "TARGET", "MARGIT", "MARGET", "TURGEN", "BERGEN", "MORGEN",
-		"JORGEN", "HAUGEN", "JERGEN", "JURGEN", "LINGEN", "BORGEN", "LANGEN", "KLAGEN", "STIGER", "BERGER") &&
+	if (e.listAt(-3, internalHardGenGinGetGitWords) &&
 		!e.stringAt(0, "GENETIC", "GENESIS") && !e.stringAt(-4, "PLANGENT")) ||
 		e.stringAtEnd(-3, "BERGIN", "FEAGIN", "DURGIN") ||
 		(e.stringAt(-2, "ENGEN") && !e.stringAt(3, "DER", "ETI", "ESI")) ||
 		e.stringAt(-4, "JUERGEN") ||
 		e.stringStart("NAGIN", "MAGIN", "HAGIN") ||
 		e.stringExact("ENGIN", "DEGEN", "LAGEN", "MAGEN", "NAGIN") ||
-		(e.stringAt(-2, "BEGET", "BEGIN", "HAGEN", "FAGIN", "BOGEN", "WIGIN", "NTGEN", "EIGEN",
-			"WEGEN", "WAGEN") &&
+		(e.listAt(-2, internalHardGenGinGetGitWords2) &&
 			!e.stringAt(-5, "OSPHAGEN")) {
 		return true
 	}
@@ -1389,7 +1363,7 @@ func (e *Encoder) internalHardGenGinGetGit() bool {
 
 func (e *Encoder) internalHardGOpenSyllable() bool {
 	return e.stringAt(1, "EYE") ||
-		e.stringAt(-2, "FOGY", "POGY", "YOGI", "MAGEE", "MCGEE", "HAGIO") ||
+		e.listAt(-2, internalHardGOpenSyllableWords) ||
 		e.stringAt(-1, "RGEY", "OGEY") ||
 		e.stringAt(-3, "HOAGY", "STOGY", "PORGY") ||
 		e.stringAt(-5, "CARNEGIE") ||
@@ -1397,13 +1371,12 @@ func (e *Encoder) internalHardGOpenSyllable() bool {
 }
 
 func (e *Encoder) internalHardGOther() bool {
-	if (e.stringAt(0, "GETH", "GEAR", "GEIS", "GIRL", "GIVI", "GIVE", "GIFT", "GIRD", "GIRT", "GILV",
-		"GILD", "GELD") && !e.stringAt(-3, "GINGIV")) ||
+	if (e.listAt(0, internalHardGOtherWords) && !e.stringAt(-3, "GINGIV")) ||
 		// "gish" but not "largish"
 		(e.stringAt(1, "ISH") && e.idx > 0 && !e.stringStart("LARG")) ||
 		(e.stringAt(-2, "MAGED", "MEGID") && e.idx+2 != e.lastIdx) ||
 		e.stringAt(0, "GEZ") ||
-		e.stringStart("WEGE", "HAGE", "VOEGE", "BERGE", "HELGE", "INGEBORG", "CORREGIDOR") ||
+		e.listStart(internalHardGOtherWords2) ||
 		(e.stringAtEnd(-2, "ONGEST", "UNGEST") && !e.stringAt(-3, "CONGEST")) ||
 		e.stringExact("ENGE", "BOGY") ||
 		e.stringAt(0, "GIBBON") ||
@@ -1420,7 +1393,6 @@ func (e *Encoder) encodeGaToJ() bool {
 	// but not in spanish forms such as "margarita"
 	if (e.stringAt(-3, "MARGARY", "MARGARI") && !e.stringAt(-3, "MARGARIT")) ||
 		e.stringStart("GAOL") || e.stringAt(-2, "ALGAE") {
-
 		e.metaphAddExactApproxAlt("J", "G", "J", "K")
 		e.advanceCounter(1, 0)
 		return true
@@ -1428,21 +1400,27 @@ func (e *Encoder) encodeGaToJ() bool {
 	return false
 }
 
-func (e *Encoder) encodeH() {
-	if e.encodeInitialSilentH() || e.encodeInitialHs() ||
-		e.encodeInitialHuHw() || e.encodeNonInitialSilentH() {
-		return
-	}
+// rulesH are tried in order for a 'H', the first one that matches encodes it
+var rulesH = []rule{
+	{name: "encodeInitialSilentH", do: (*Encoder).encodeInitialSilentH},
+	{name: "encodeInitialHs", do: (*Encoder).encodeInitialHs},
+	{name: "encodeInitialHuHw", do: (*Encoder).encodeInitialHuHw},
+	{name: "encodeNonInitialSilentH", do: (*Encoder).encodeNonInitialSilentH},
+	{name: "encodeH", do: (*Encoder).encodeH},
+}
 
+// encodeH is the general handling of a 'H' when none of the special cases match
+func (e *Encoder) encodeH() bool {
 	// only keep if first & before vowel or btw. 2 vowels
 	if !e.encodeHPronounced() {
 		//e.idx++ ?
 	}
+	return true
 }
 
 func (e *Encoder) encodeInitialSilentH() bool {
 	// 'hour', 'herb', 'heir', 'honor'
-	if e.stringAt(1, "OUR", "ERB", "EIR", "ONOR", "ONOUR", "ONEST") {
+	if e.listAt(1, encodeInitialSilentHWords) {
 		// british pronounce H in this word
 		// americans give it 'H' for the name,
 		// no 'H' for the plant
@@ -1498,8 +1476,8 @@ func (e *Encoder) encodeInitialHuHw() bool {
 }
 
 func (e *Encoder) encodeNonInitialSilentH() bool {
-	if e.stringAt(-2, "NIHIL", "VEHEM", "LOHEN", "NEHEM", "MAHON", "MAHAN", "COHEN", "GAHAN") ||
-		e.stringAt(-3, "TOUHY", "GRAHAM", "PROHIB", "FRAHER", "TOOHEY", "TOUHEY") ||
+	if e.listAt(-2, encodeNonInitialSilentHWords) ||
+		e.listAt(-3, encodeNonInitialSilentHWords2) ||
 		e.stringStart("CHIHUAHUA") {
 		if e.EncodeVowels {
 			e.idx++
@@ -1519,27 +1497,31 @@ func (e *Encoder) encodeHPronounced() bool {
 
 		e.metaphAdd('H')
 		e.advanceCounter(1, 0)
-		return true
+		return e.fired("encodeHPronounced")
 	}
 
 	return false
 }
 
-func (e *Encoder) encodeJ() {
-	if e.encodeSpanishJ() || e.encodeSpanishOjUj() {
-		return
-	}
+// rulesJ are tried in order for a 'J', the first one that matches encodes it
+var rulesJ = []rule{
+	{name: "encodeSpanishJ", do: (*Encoder).encodeSpanishJ},
+	{name: "encodeSpanishOjUj", do: (*Encoder).encodeSpanishOjUj},
+	{name: "encodeJ", do: (*Encoder).encodeJ},
+}
 
+// encodeJ is the general handling of a 'J' when none of the special cases match
+func (e *Encoder) encodeJ() bool {
 	//e.encodeOtherJ()
 	if e.idx == 0 {
 		if e.encodeGermanJ() {
-			return
+			return true
 		} else if e.encodeJToJ() {
-			return
+			return true
 		}
 	} else {
 		if e.encodeSpanishJ2() {
-			return
+			return true
 		} else if !e.encodeJAsVowel() {
 			e.metaphAdd('J')
 		}
@@ -1550,18 +1532,17 @@ func (e *Encoder) encodeJ() {
 			e.idx++
 		}
 	}
+	return true
 }
 
 func (e *Encoder) encodeSpanishJ() bool {
 	//obvious spanish, e.g. "jose", "san jacinto"
-	if (e.stringAt(1, "UAN", "ACI", "ALI", "EFE", "ICA", "IME", "OAQ", "UAR") &&
+	if (e.listAt(1, encodeSpanishJWords2) &&
 		!e.stringAt(0, "JIMERSON", "JIMERSEN")) ||
 		e.stringAtEnd(1, "OSE") ||
-		e.stringAt(1, "EREZ", "UNTA", "AIME", "AVIE", "AVIA", "IMINEZ", "ARAMIL") ||
+		e.listAt(1, encodeSpanishJWords3) ||
 		e.stringAtEnd(-2, "MEJIA") ||
-		e.stringAt(-2, "TEJED", "TEJAD", "LUJAN", "FAJAR", "BEJAR", "BOJOR", "CAJIG",
-			"DEJAS", "DUJAR", "DUJAN", "MIJAR", "MEJOR", "NAJAR",
-			"NOJOS", "RAJED", "RIJAL", "REJON", "TEJAN", "UIJAN") ||
+		e.listAt(-2, encodeSpanishJWords) ||
 		e.stringAt(-3, "ALEJANDR", "GUAJARDO", "TRUJILLO") ||
 		(e.stringAt(-2, "RAJAS") && e.idx > 2) ||
 		(e.stringAt(-2, "MEJIA") && !e.stringAt(-2, "MEJIAN")) ||
@@ -1571,7 +1552,6 @@ func (e *Encoder) encodeSpanishJ() bool {
 		e.stringAt(-4, "HINOJOSA") ||
 		e.stringStart("SAN ") ||
 		((e.idx+1 == e.lastIdx) && e.charAt(1, 'O') && !e.stringStart("TOJO", "BANJO", "MARYJO")) {
-
 		// americans pronounce "juan" as 'wan'
 		// and "marijuana" and "tijuana" also
 		// do not get the 'H' as in spanish, so
@@ -1591,14 +1571,14 @@ func (e *Encoder) encodeSpanishJ() bool {
 		// get both consonants for "jorge"
 		if e.stringAtEnd(1, "ORGE") {
 			if e.EncodeVowels {
-				e.metaphAddStr("JARJ", "HARHA")
+				e.metaphAddStrWeight("JARJ", "HARHA", altUnlikely)
 			} else {
-				e.metaphAddStr("JRJ", "HRH")
+				e.metaphAddStrWeight("JRJ", "HRH", altUnlikely)
 			}
 			e.advanceCounter(4, 4)
 			return true
 		}
-		e.metaphAddAlt('J', 'H')
+		e.metaphAddAltWeight('J', 'H', altUnlikely)
 		e.advanceCounter(1, 0)
 		return true
 	}
@@ -1612,14 +1592,14 @@ func (e *Encoder) encodeGermanJ() bool {
 
 		e.metaphAdd('A')
 		e.advanceCounter(1, 0)
-		return true
+		return e.fired("encodeGermanJ")
 	}
 
 	return false
 }
 
 func (e *Encoder) encodeSpanishOjUj() bool {
-	if e.stringAt(1, "OJOBA", "UJUY") {
+	if e.stringAt(1, "UJUY", "OJOBA") {
 		if e.EncodeVowels {
 			e.metaphAddStr("HAH", "HAH")
 		} else {
@@ -1637,11 +1617,11 @@ func (e *Encoder) encodeJToJ() bool {
 	if e.isVowelAt(1) {
 		if e.idx == 0 && e.namesBeginningWithJThatGetAltY() {
 			// 'Y' is a vowel so encode
-			// is as 'A'
+			// is as 'A'.  the 'Y' pronunciation is the rarer one
 			if e.EncodeVowels {
-				e.metaphAddStr("JA", "A")
+				e.metaphAddStrWeight("JA", "A", altUnlikely)
 			} else {
-				e.metaphAddAlt('J', 'A')
+				e.metaphAddAltWeight('J', 'A', altUnlikely)
 			}
 		} else {
 			if e.EncodeVowels {
@@ -1655,19 +1635,19 @@ func (e *Encoder) encodeJToJ() bool {
 	}
 
 	e.metaphAdd('J')
-	return true
+	return e.fired("encodeJToJ")
 }
 
 func (e *Encoder) encodeSpanishJ2() bool {
 	// spanish forms e.g. "brujo", "badajoz"
-	if e.stringAtStart(-2, "BOJA", "BAJA", "BEJA", "BOJO", "MOJA", "MOJI", "MEJI") ||
-		e.stringAtStart(-3, "FRIJO", "BRUJO", "BRUJA", "GRAJE", "GRIJA", "LEIJA", "QUIJA") ||
-		e.stringAtEnd(-1, "AJOS", "EJOS", "OJAS", "OJOS", "UJON", "AJOZ", "AJAL", "UJAR", "EJON", "EJAN", "AJARA") ||
+	if e.listAtStart(-2, encodeSpanishJ2Words) ||
+		e.listAtStart(-3, encodeSpanishJ2Words2) ||
+		e.listAtEnd(-1, encodeSpanishJ2Words3) ||
 		(e.stringAtEnd(-1, "OJA", "EJA") && !e.stringStart("DEJA")) {
 
 		e.metaphAdd('H')
 		e.advanceCounter(1, 0)
-		return true
+		return e.fired("encodeSpanishJ2")
 	}
 
 	return false
@@ -1676,124 +1656,64 @@ func (e *Encoder) encodeSpanishJ2() bool {
 func (e *Encoder) encodeJAsVowel() bool {
 	if e.stringAt(0, "JEWSK") {
 		e.metaphAddAlt('J', unicode.ReplacementChar)
-		return true
+		return e.fired("encodeJAsVowel")
 	}
 
 	// e.g. "stijl", "sejm" - dutch, scandanavian, and eastern european spellings
 	// except words from hindi and arabic
-	if (e.stringAt(1, "L", "T", "K", "S", "N", "M") && !e.stringAt(2, "A")) ||
-		e.stringStart("FJ", "WOJ", "LJUB", "BJOR", "HAJEK", "HALLELUJA", "LJUBLJANA") ||
+	if (e.listAt(1, encodeJAsVowelWords) && !e.stringAt(2, "A")) ||
+		e.listStart(encodeJAsVowelWords2) ||
 		// e.g. 'rekjavik', 'blagojevic'
 		e.stringAt(0, "JAVIK", "JEVIC") ||
 		e.stringExact("SONJA", "TANJA", "TONJA") {
 
-		return true
+		return e.fired("encodeJAsVowel")
 	}
 
 	return false
 }
 
 func (e *Encoder) namesBeginningWithJThatGetAltY() bool {
-	return e.stringStart("JAN", "JON", "JAN", "JIN", "JEN",
-		"JUHL", "JULY", "JOEL", "JOHN", "JOSH", "JUDE", "JUNE", "JONI", "JULI", "JENA",
-		"JUNG", "JINA", "JANA", "JENI", "JOEL", "JANN", "JONA", "JENE", "JULE", "JANI", "JONG", "JOHN",
-		"JEAN", "JUNG", "JONE", "JARA", "JUST", "JOST", "JAHN", "JACO", "JANG", "JUDE", "JONE",
-		"JOANN", "JANEY", "JANAE", "JOANA", "JUTTA", "JULEE", "JANAY", "JANEE", "JETTA",
-		"JOHNA", "JOANE", "JAYNA", "JANES", "JONAS", "JONIE", "JUSTA", "JUNIE", "JUNKO", "JENAE",
-		"JULIO", "JINNY", "JOHNS", "JACOB", "JETER", "JAFFE", "JESKE", "JANKE", "JAGER", "JANIK",
-		"JANDA", "JOSHI", "JULES", "JANTZ", "JEANS", "JUDAH", "JANUS", "JENNY", "JENEE", "JONAH",
-		"JONAS", "JACOB", "JOSUE", "JOSEF", "JULES", "JULIE", "JULIA", "JANIE", "JANIS", "JENNA",
-		"JANNA", "JEANA", "JENNI", "JEANE", "JONNA", "JAKOB",
-		"JORDAN", "JORDON", "JOSEPH", "JOSHUA", "JOSIAH", "JOSPEH", "JUDSON", "JULIAN",
-		"JULIUS", "JUNIOR", "JUDITH", "JOESPH", "JOHNIE", "JOANNE", "JEANNE", "JOANNA", "JOSEFA",
-		"JULIET", "JANNIE", "JANELL", "JASMIN", "JANINE", "JOHNNY", "JEANIE", "JEANNA", "JOHNNA",
-		"JOELLE", "JOVITA", "JOSEPH", "JONNIE", "JANEEN", "JANINA", "JOANIE", "JAZMIN", "JOHNIE",
-		"JANENE", "JOHNNY", "JONELL", "JENELL", "JANETT", "JANETH", "JENINE", "JOELLA", "JOEANN",
-		"JULIAN", "JOHANA", "JENICE", "JANNET", "JANISE", "JULENE", "JOSHUA", "JANEAN", "JAIMEE",
-		"JOETTE", "JANYCE", "JENEVA", "JORDAN", "JACOBS", "JENSEN", "JOSEPH", "JANSEN", "JORDON",
-		"JULIAN", "JAEGER", "JACOBY", "JENSON", "JARMAN", "JOSLIN", "JESSEN", "JAHNKE", "JACOBO",
-		"JULIEN", "JOSHUA", "JEPSON", "JULIUS", "JANSON", "JACOBI", "JUDSON", "JARBOE", "JOHSON",
-		"JANZEN", "JETTON", "JUNKER", "JONSON", "JAROSZ", "JENNER", "JAGGER", "JASMIN", "JEPSEN",
-		"JORDEN", "JANNEY", "JUHASZ", "JERGEN",
-		"JOHNSON", "JOHNNIE", "JASMINE", "JEANNIE", "JOHANNA", "JANELLE", "JANETTE",
-		"JULIANA", "JUSTINA", "JOSETTE", "JOELLEN", "JENELLE", "JULIETA", "JULIANN", "JULISSA",
-		"JENETTE", "JANETTA", "JOSELYN", "JONELLE", "JESENIA", "JANESSA", "JAZMINE", "JEANENE",
-		"JOANNIE", "JADWIGA", "JOLANDA", "JULIANE", "JANUARY", "JEANICE", "JANELLA", "JEANETT",
-		"JENNINE", "JOHANNE", "JOHNSIE", "JANIECE", "JOHNSON", "JENNELL", "JAMISON", "JANSSEN",
-		"JOHNSEN", "JARDINE", "JAGGERS", "JURGENS", "JOURDAN", "JULIANO", "JOSEPHS", "JHONSON",
-		"JOZWIAK", "JANICKI", "JELINEK", "JANSSON", "JOACHIM", "JANELLE", "JACOBUS", "JENNING",
-		"JANTZEN", "JOHNNIE",
-		"JOSEFINA", "JEANNINE", "JULIANNE", "JULIANNA", "JONATHAN", "JONATHON", "JEANETTE",
-		"JANNETTE", "JEANETTA", "JOHNETTA", "JENNEFER", "JULIENNE", "JOSPHINE", "JEANELLE", "JOHNETTE",
-		"JULIEANN", "JOSEFINE", "JULIETTA", "JOHNSTON", "JACOBSON", "JACOBSEN", "JOHANSEN", "JOHANSON",
-		"JAWORSKI", "JENNETTE", "JELLISON", "JOHANNES", "JASINSKI", "JUERGENS", "JARNAGIN", "JEREMIAH",
-		"JEPPESEN", "JARNIGAN", "JANOUSEK",
-		"JOHNATHAN", "JOHNATHON", "JORGENSEN", "JEANMARIE", "JOSEPHINA", "JEANNETTE",
-		"JOSEPHINE", "JEANNETTA", "JORGENSON", "JANKOWSKI", "JOHNSTONE", "JABLONSKI", "JOSEPHSON",
-		"JOHANNSEN", "JURGENSEN", "JIMMERSON", "JOHANSSON",
-		"JAKUBOWSKI")
-}
-
-func (e *Encoder) encodeK() {
-	if !e.encodeSilentK() {
-		e.metaphAdd('K')
-
-		// eat redundant K's and Q's
-		if e.charAt(1, 'K') || e.charAt(1, 'Q') {
-			e.idx++
-		}
-	}
+	return e.listStart(namesBeginningWithJThatGetAltYWords)
 }
 
-func (e *Encoder) encodeSilentK() bool {
-	if e.idx == 0 && e.stringStart("KN") {
-		if !e.stringAt(2, "ISH", "ESSET", "IEVEL") {
-			return true
-		}
-	}
-
-	// e.g. "know", "knit", "knob"
-	if (e.stringAt(1, "NOW", "NIT", "NOT", "NOB") && !e.stringStart("BANKNOTE")) ||
-		e.stringAt(1, "NOCK", "NUCK", "NIFE", "NACK", "NIGHT") {
-		// N already encoded before
-		// e.g. "penknife"
-		if e.idx > 0 && e.charAt(-1, 'N') {
-			e.idx++
-		}
-
-		return true
-	}
-
-	return false
+// rulesL are tried in order for an 'L', the first one that matches encodes it
+var rulesL = []rule{
+	{name: "interpolateVowelWhenConsLAtEnd", do: (*Encoder).interpolateVowelWhenConsLAtEnd, cont: true},
+	{name: "encodeLelyToL", do: (*Encoder).encodeLelyToL},
+	{name: "encodeColonel", do: (*Encoder).encodeColonel},
+	{name: "encodeFrenchAult", do: (*Encoder).encodeFrenchAult},
+	{name: "encodeFrenchEuil", do: (*Encoder).encodeFrenchEuil},
+	{name: "encodeFrenchOulx", do: (*Encoder).encodeFrenchOulx},
+	{name: "encodeSilentLInLm", do: (*Encoder).encodeSilentLInLm},
+	{name: "encodeSilentLInLkLv", do: (*Encoder).encodeSilentLInLkLv},
+	{name: "encodeSilentLInOuld", do: (*Encoder).encodeSilentLInOuld},
+	{name: "encodeL", do: (*Encoder).encodeL},
 }
 
-func (e *Encoder) encodeL() {
+// encodeL is the general handling of an 'L' when none of the special cases match
+func (e *Encoder) encodeL() bool {
 	// logic below needs to know this
 	// after 'm_current' variable changed
 	saveIdx := e.idx
 
-	e.interpolateVowelWhenConsLAtEnd()
-
-	if e.encodeLelyToL() || e.encodeColonel() || e.encodeFrenchAult() || e.encodeFrenchEuil() ||
-		e.encodeFrenchOulx() || e.encodeSilentLInLm() || e.encodeSilentLInLkLv() ||
-		e.encodeSilentLInOuld() {
-		return
-	}
-
 	if e.encodeLlAsVowelCases() {
-		return
+		return true
 	}
 
 	e.encodeLeCases(saveIdx)
+	return true
 }
 
 //Cases where an L follows D, G, or T at the end have a schwa pronounced before
 //the L
-func (e *Encoder) interpolateVowelWhenConsLAtEnd() {
+func (e *Encoder) interpolateVowelWhenConsLAtEnd() bool {
 	// e.g. "ertl", "vogl"
 	if e.EncodeVowels && e.stringAtEnd(-1, "DL", "GL", "TL") {
 		e.metaphAdd('A')
+		return true
 	}
+	return false
 }
 
 func (e *Encoder) encodeLelyToL() bool {
@@ -1818,9 +1738,8 @@ func (e *Encoder) encodeColonel() bool {
 func (e *Encoder) encodeFrenchAult() bool {
 	// e.g. "renault" and "foucault", well known to americans, but not "fault"
 	if e.idx > 3 &&
-		(e.stringAt(-3, "RAULT", "NAULT", "BAULT", "SAULT", "GAULT", "CAULT") || e.stringAt(-4, "REAULT", "RIAULT", "NEAULT", "BEAULT")) &&
+		(e.listAt(-3, encodeFrenchAultWords) || e.stringAt(-4, "REAULT", "RIAULT", "NEAULT", "BEAULT")) &&
 		!(rootOrInflections(e.in, "ASSAULT") || e.stringAt(-8, "SOMERSAULT") || e.stringAt(-9, "SUMMERSAULT")) {
-
 		e.idx++
 		return true
 	}
@@ -1869,13 +1788,12 @@ func (e *Encoder) encodeSilentLInLm() bool {
 }
 
 func (e *Encoder) encodeSilentLInLkLv() bool {
-	if (e.stringAt(-2, "WALK", "YOLK", "FOLK", "HALF", "TALK", "CALF", "BALK", "CALK") ||
-		(e.stringAt(-2, "POLK", "HALV", "SALVE", "CALVE", "SOLDER") && !e.stringAt(-2, "POLKA", "PALKO", "HALVA", "HALVO", "SALVER", "CALVER")) ||
+	if (e.listAt(-2, encodeSilentLInLkLvWords) ||
+		(e.stringAt(-2, "POLK", "HALV", "SALVE", "CALVE", "SOLDER") && !e.listAt(-2, encodeSilentLInLkLvWords2)) ||
 		(e.stringAt(-3, "CAULK", "CHALK", "BAULK", "FAULK") && !e.stringAt(-4, "SCHALK"))) &&
 		!e.stringAt(-5, "GONSALVES", "GONCALVES") &&
 		!e.stringAt(-2, "BALKAN", "TALKAL") &&
 		!e.stringAt(-3, "PAULK", "CHALF") {
-
 		return true
 	}
 
@@ -1908,7 +1826,7 @@ func (e *Encoder) encodeLlAsVowelSpecialCases() bool {
 		(e.stringAtEnd(-2, "EILLE") && !e.stringAt(-5, "REVEILLE")) {
 
 		e.idx++
-		return true
+		return e.fired("encodeLlAsVowelSpecialCases")
 	}
 
 	return false
@@ -1920,17 +1838,18 @@ func (e *Encoder) encodeLlAsVowel() bool {
 	// in the spanish or the american fashion.
 	if e.stringAtEnd(-1, "ILLO", "ILLA", "ALLE") ||
 		(e.stringEnd("A", "O", "AS", "OS") && e.stringAt(-1, "AL", "IL") && !e.stringAt(-1, "ALLA")) ||
-		e.stringStart("LLA", "VILLE", "VILLA", "GALLARDO", "VALLADAR", "MAGALLAN", "CAVALLAR", "BALLASTE") {
+		e.listStart(encodeLlAsVowelWords) {
 
 		e.metaphAddAlt('L', unicode.ReplacementChar)
 		e.idx++
-		return true
+		return e.fired("encodeLlAsVowel")
 	}
 	return false
 }
 
 func (e *Encoder) encodeLlAsVowelCases() bool {
 	if e.charNextIs('L') {
+		e.fired("encodeLlAsVowelCases")
 		if e.encodeLlAsVowelSpecialCases() {
 			return true
 		} else if e.encodeLlAsVowel() {
@@ -1950,10 +1869,9 @@ func (e *Encoder) encodeVowelLeTransposition(idx int) bool {
 		!e.charAt(offset-1, 'L') && !e.charAt(offset-1, 'R') &&
 		// lots of exceptions to this:
 		!e.isVowelAt(offset+2) &&
-		!e.stringStart("MCCLE", "MCLEL", "EMBLEM", "KADLEC", "ECCLESI", "COMPLEC", "COMPLEJ", "ROBLEDO") &&
+		!e.listStart(encodeVowelLeTranspositionWords2) &&
 		!(idx+2 == e.lastIdx && e.stringAt(offset, "LET")) &&
-		!e.stringAt(offset, "LEG", "LER", "LEX", "LESS", "LESQ", "LECT", "LEDG", "LETE", "LETH", "LETS", "LETT",
-			"LETUS", "LETIV", "LETELY", "LETTER", "LETION", "LETIAN", "LETING", "LETORY", "LETTING") &&
+		!e.listAt(offset, encodeVowelLeTranspositionWords) &&
 		// e.g. "complement" !=> KAMPALMENT
 		!(e.stringAt(offset, "LEMENT") &&
 			!(e.stringAt(-4, "BATTLE", "TANGLE", "PUZZLE", "RABBLE", "BABBLE") || e.stringAt(-3, "TABLE"))) &&
@@ -1972,7 +1890,7 @@ func (e *Encoder) encodeVowelLeTransposition(idx int) bool {
 		if e.charAt(offset+2, 'L') {
 			e.idx = idx + 2
 		}
-		return true
+		return e.fired("encodeVowelLeTransposition")
 	}
 
 	return false
@@ -1988,7 +1906,7 @@ func (e *Encoder) encodeVowelPreserveVowelAfterL(idx int) bool {
 
 		e.metaphAddStr("LA", "LA")
 		e.idx = e.skipVowels(e.idx + 1)
-		return true
+		return e.fired("encodeVowelPreserveVowelAfterL")
 	}
 
 	return false
@@ -2002,24 +1920,34 @@ func (e *Encoder) encodeLeCases(idx int) {
 	if e.encodeVowelPreserveVowelAfterL(idx) {
 		return
 	}
+	e.fired("encodeLeCases")
 	e.metaphAdd('L')
 }
 
-func (e *Encoder) encodeM() {
-	if e.encodeSilentMAtBeginning() || e.encodeMrAndMrs() ||
-		e.encodeMac() || e.encodeMpt() {
-		return
-	}
+// rulesM are tried in order for a 'M', the first one that matches encodes it
+var rulesM = []rule{
+	{name: "encodeSilentMAtBeginning", do: (*Encoder).encodeSilentMAtBeginning},
+	{name: "encodeMrAndMrs", do: (*Encoder).encodeMrAndMrs},
+	{name: "encodeMac", do: (*Encoder).encodeMac},
+	{name: "encodeMpt", do: (*Encoder).encodeMpt},
+	{name: "encodeM", do: (*Encoder).encodeM},
+}
 
+// encodeM is the general handling of a 'M' when none of the special cases match
+func (e *Encoder) encodeM() bool {
 	// Silent 'B' should really be handled
 	// under 'B", not here under 'M'!
 	e.encodeMb()
 
 	e.metaphAdd('M')
+	return true
 }
 
 func (e *Encoder) encodeSilentMAtBeginning() bool {
-	return e.stringAtStart(0, "MN")
+	if e.stringAtStart(0, "MN") {
+		return true
+	}
+	return false
 }
 
 func (e *Encoder) encodeMrAndMrs() bool {
@@ -2047,7 +1975,7 @@ func (e *Encoder) encodeMrAndMrs() bool {
 func (e *Encoder) encodeMac() bool {
 	// should only find irish and
 	// scottish names e.g. 'macintosh'
-	if e.stringAtStart(0, "MC", "MACIVER", "MACEWEN", "MACELROY", "MACILROY", "MACINTOSH") {
+	if e.listAtStart(0, encodeMacWords) {
 		if e.EncodeVowels {
 			e.metaphAddStr("MAK", "MAK")
 		} else {
@@ -2084,7 +2012,7 @@ func (e *Encoder) encodeMpt() bool {
 func (e *Encoder) testSilentMb1() bool {
 	// e.g. "LAMB", "COMB", "LIMB", "DUMB", "BOMB"
 	// Handle combining roots first
-	return e.stringAtStart(-3, "THUMB") || e.stringAtStart(-2, "DUMB", "BOMB", "DAMN", "LAMB", "NUMB", "TOMB")
+	return e.stringAtStart(-3, "THUMB") || e.listAtStart(-2, testSilentMb1Words)
 }
 
 func (e *Encoder) testPronouncedMb() bool {
@@ -2118,7 +2046,7 @@ func (e *Encoder) testPronouncedMb2() bool {
 func (e *Encoder) testMn() bool {
 	return e.charNextIs('N') && (e.idx+1 == e.lastIdx ||
 		// or at the end of a word but followed by suffixes
-		e.stringAtEnd(2, "S", "LY", "ER", "ED", "ING", "EST") ||
+		e.listAtEnd(2, testMnWords) ||
 		e.stringAt(-2, "DAMNEDEST") ||
 		e.stringAt(-5, "GODDAMNIT"))
 }
@@ -2127,192 +2055,27 @@ func (e *Encoder) encodeMb() {
 	if e.testSilentMb1() {
 		if !e.testPronouncedMb() {
 			e.idx++
+			e.fired("encodeMb")
 		}
 	} else if e.testSilentMb2() {
 		if !e.testPronouncedMb2() {
 			e.idx++
+			e.fired("encodeMb")
 		}
 	} else if e.testMn() || e.charNextIs('M') {
 		e.idx++
+		e.fired("encodeMb")
 	}
 }
 
-func (e *Encoder) encodeN() {
-	if e.encodeNce() {
-		return
-	}
-
-	//eat redundant 'N'
-	if e.charNextIs('N') {
-		e.idx++
-	}
-
-	// e.g. "aloneness",
-	if !e.stringAt(-2, "MONSIEUR") && !e.stringAt(-2, "NENESS") {
-		e.metaphAdd('N')
-	}
-}
-
-//Encode "-NCE-" and "-NSE-" "entrance" is pronounced exactly the same as
-//"entrants"
-func (e *Encoder) encodeNce() bool {
-	// 'acceptance', 'accountancy'
-	if e.stringAt(1, "C", "S") && e.stringAt(2, "E", "Y", "I") &&
-		(e.idx+2 == e.lastIdx || (e.idx+3 == e.lastIdx && e.charAt(3, 'S'))) {
-
-		e.metaphAddStr("NTS", "NTS")
-		e.idx++
-		return true
-	}
-
-	return false
-}
-
-func (e *Encoder) encodeP() {
-	if e.encodeSilentPAtBeginning() || e.encodePt() || e.encodePh() ||
-		e.encodePph() || e.encodeRps() || e.encodeCoup() ||
-		e.encodePneum() || e.encodePsych() || e.encodePsalm() {
-		return
-	}
-
-	e.encodePb()
-
-	e.metaphAdd('P')
-}
-
-func (e *Encoder) encodeSilentPAtBeginning() bool {
-	return e.stringAtStart(0, "PN", "PF", "PS", "PT")
-}
-
-func (e *Encoder) encodePt() bool {
-	// 'pterodactyl', 'receipt', 'asymptote'
-	if e.charNextIs('T') &&
-		(e.stringAtStart(0, "PTERO") || e.stringAt(-5, "RECEIPT") || e.stringAt(-4, "ASYMPTOT")) {
-
-		e.metaphAdd('T')
-		e.idx++
-		return true
-	}
-
-	return false
-}
-
-//Encode "-PH-", usually as F, with exceptions for cases where it is silent, or
-//where the 'P' and 'T' are pronounced seperately because they belong to two
-//different words in a combining form
-func (e *Encoder) encodePh() bool {
-	if e.charNextIs('H') {
-		// 'PH' silent in these contexts
-		if e.stringAt(0, "PHTHALEIN") || e.stringAtStart(0, "PHTH") || e.stringAt(-3, "APOPHTHEGM") {
-			e.metaphAdd('0')
-			e.idx += 3
-		} else if e.idx > 0 &&
-			(e.stringAt(2, "AM", "EAD", "OLE", "ELD", "ILL", "OLD", "EAP", "ERD", "ARD", "ANG",
-				"ORN", "EAV", "ART", "OUSE", "AMMER", "AZARD", "UGGER", "OLSTER") && !e.stringAt(-1, "LPHAM")) &&
-			!e.stringAt(-3, "LYMPH", "NYMPH") {
-			// combining forms
-			// 'sheepherd', 'upheaval', 'cupholder'
-			e.metaphAdd('P')
-			e.advanceCounter(2, 1)
-		} else {
-			e.metaphAdd('F')
-			e.idx++
-		}
-
-		return true
-	}
-
-	return false
-}
-
-func (e *Encoder) encodePph() bool {
-	// 'sappho'
-	if e.charNextIs('P') && e.idx+2 < len(e.in) && e.charAt(2, 'H') {
-		e.metaphAdd('F')
-		e.idx += 2
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeRps() bool {
-	// '-corps-', 'corpsman'
-	if e.stringAt(-3, "CORPS") && !e.stringAt(-3, "CORPSE") {
-		e.idx++
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeCoup() bool {
-	// 'coup'
-	return e.stringAtEnd(-3, "COUP") && !e.stringAt(-5, "RECOUP")
-}
-
-func (e *Encoder) encodePneum() bool {
-	// '-pneum-'
-	if e.stringAt(1, "NEUM") {
-		e.metaphAdd('N')
-		e.idx++
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodePsych() bool {
-	// '-psych-'
-	if e.stringAt(1, "SYCH") {
-		if e.EncodeVowels {
-			e.metaphAddStr("SAK", "SAK")
-		} else {
-			e.metaphAddStr("SK", "SK")
-		}
-		e.idx += 4
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodePsalm() bool {
-	if e.stringAt(1, "SALM") {
-		if e.EncodeVowels {
-			e.metaphAddStr("SAM", "SAM")
-		} else {
-			e.metaphAddStr("SM", "SM")
-		}
-		e.idx += 4
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodePb() {
-	// e.g. "campbell", "raspberry"
-	// eat redundant 'P' or 'B'
-	if e.stringAt(1, "P", "B") {
-		e.idx++
-	}
-}
-
-func (e *Encoder) encodeQ() {
-	// current pinyin
-	if e.stringAt(0, "QIN") {
-		e.metaphAdd('X')
-		return
-	}
-
-	// eat redundant 'Q'
-	if e.charNextIs('Q') {
-		e.idx++
-	}
-	e.metaphAdd('K')
+// rulesR are tried in order for a 'R', the first one that matches encodes it
+var rulesR = []rule{
+	{name: "encodeRz", do: (*Encoder).encodeRz},
+	{name: "encodeR", do: (*Encoder).encodeR},
 }
 
-func (e *Encoder) encodeR() {
-	if e.encodeRz() {
-		return
-	}
-
+// encodeR is the general handling of a 'R' when none of the special cases match
+func (e *Encoder) encodeR() bool {
 	if !e.testSilentR() && !e.encodeVowelReTransposition() {
 		e.metaphAdd('R')
 	}
@@ -2321,11 +2084,12 @@ func (e *Encoder) encodeR() {
 	if e.charNextIs('R') || e.stringAt(-6, "POITIERS") {
 		e.idx++
 	}
+	return true
 }
 
 //Encode "-RZ-" according to american and polish pronunciations
 func (e *Encoder) encodeRz() bool {
-	if e.stringAt(-2, "GARZ", "KURZ", "MARZ", "MERZ", "HERZ", "PERZ", "WARZ") ||
+	if e.listAt(-2, encodeRzWords) ||
 		e.stringAt(0, "RZANO", "RZOLA") || e.stringAt(-1, "ARZA", "ARZN") {
 		return false
 	}
@@ -2377,13 +2141,11 @@ func (e *Encoder) testSilentR() bool {
 		// e.g. "metier"
 		(e.stringAt(-5, "MET", "VIV", "LUC") ||
 			// e.g. "cartier", "bustier"
-			e.stringAt(-6, "CART", "DOSS", "FOUR", "OLIV", "BUST", "DAUM", "ATEL", "SONN",
-				"CORM", "MERC", "PELT", "POIR", "BERN", "FORT", "GREN", "SAUC", "GAGN", "GAUT", "GRAN",
-				"FORC", "MESS", "LUSS", "MEUN", "POTH", "HOLL", "CHEN") ||
+			e.listAt(-6, testSilentRWords) ||
 			// e.g. "croupier"
-			e.stringAt(-7, "CROUP", "TORCH", "CLOUT", "FOURN", "GAUTH", "TROTT", "DEROS", "CHART") ||
+			e.listAt(-7, testSilentRWords2) ||
 			// e.g. "chevalier"
-			e.stringAt(-8, "CHEVAL", "LAVOIS", "PELLET", "SOMMEL", "TREPAN", "LETELL", "COLOMB") ||
+			e.listAt(-8, testSilentRWords3) ||
 			e.stringAt(-9, "CHARCUT") || e.stringAt(-10, "CHARPENT"))) ||
 		e.stringAt(-2, "SURBURB", "WORSTED", "WORCESTER") ||
 		e.stringAt(-7, "MONSIEUR") || e.stringAt(-6, "POITIERS") {
@@ -2401,33 +2163,54 @@ func (e *Encoder) encodeVowelReTransposition() bool {
 	// e.g. "fibre" => FABAR or "centre" => SANTAR
 	if e.EncodeVowels && e.charNextIs('E') && len(e.in) > 3 &&
 		!e.stringStart("OUTRE", "LIBRE", "ANDRE") && !e.stringExact("FRED", "TRES") &&
-		!e.stringAt(-2, "LDRED", "LFRED", "NDRED", "NFRED", "NDRES", "IFRED") && //"TRES" ?
+		!e.listAt(-2, encodeVowelReTranspositionWords) && //"TRES" ?
 		!e.isVowelAt(-1) &&
 		(e.idx+1 == e.lastIdx || e.stringAtEnd(2, "D", "S")) {
 
 		e.metaphAddStr("AR", "AR")
-		return true
+		return e.fired("encodeVowelReTransposition")
 	}
 
 	return false
 }
 
 //650
-func (e *Encoder) encodeS() {
-	if e.encodeSkj() || e.encodeSpecialSw() || e.encodeSj() || e.encodeSilentFrenchSFinal() ||
-		e.encodeSilentFrenchSInternal() || e.encodeIsl() || e.encodeStl() || e.encodeChristmas() ||
-		e.encodeSthm() || e.encodeIsten() || e.encodeSugar() || e.encodeSh() || e.encodeSch() ||
-		e.encodeSur() || e.encodeSu() || e.encodeSsio() || e.encodeSs() || e.encodeSia() ||
-		e.encodeSio() || e.encodeAnglicisations() || e.encodeSc() || e.encodeSeiSuiSier() ||
-		e.encodeSea() {
-		return
-	}
-
+// rulesS are tried in order for a 'S', the first one that matches encodes it
+var rulesS = []rule{
+	{name: "encodeSkj", do: (*Encoder).encodeSkj},
+	{name: "encodeSpecialSw", do: (*Encoder).encodeSpecialSw},
+	{name: "encodeSj", do: (*Encoder).encodeSj},
+	{name: "encodeSilentFrenchSFinal", do: (*Encoder).encodeSilentFrenchSFinal},
+	{name: "encodeSilentFrenchSInternal", do: (*Encoder).encodeSilentFrenchSInternal},
+	{name: "encodeIsl", do: (*Encoder).encodeIsl},
+	{name: "encodeStl", do: (*Encoder).encodeStl},
+	{name: "encodeChristmas", do: (*Encoder).encodeChristmas},
+	{name: "encodeSthm", do: (*Encoder).encodeSthm},
+	{name: "encodeIsten", do: (*Encoder).encodeIsten},
+	{name: "encodeSugar", do: (*Encoder).encodeSugar},
+	{name: "encodeSh", do: (*Encoder).encodeSh},
+	{name: "encodeSch", do: (*Encoder).encodeSch},
+	{name: "encodeSur", do: (*Encoder).encodeSur},
+	{name: "encodeSu", do: (*Encoder).encodeSu},
+	{name: "encodeSsio", do: (*Encoder).encodeSsio},
+	{name: "encodeSs", do: (*Encoder).encodeSs},
+	{name: "encodeSia", do: (*Encoder).encodeSia},
+	{name: "encodeSio", do: (*Encoder).encodeSio},
+	{name: "encodeAnglicisations", do: (*Encoder).encodeAnglicisations},
+	{name: "encodeSc", do: (*Encoder).encodeSc},
+	{name: "encodeSeiSuiSier", do: (*Encoder).encodeSeiSuiSier},
+	{name: "encodeSea", do: (*Encoder).encodeSea},
+	{name: "encodeS", do: (*Encoder).encodeS},
+}
+
+// encodeS is the general handling of a 'S' when none of the special cases match
+func (e *Encoder) encodeS() bool {
 	e.metaphAdd('S')
 
 	if e.stringAt(1, "S", "Z") && !e.stringAt(1, "SH") {
 		e.idx++
 	}
+	return true
 }
 
 func (e *Encoder) encodeSkj() bool {
@@ -2457,14 +2240,11 @@ func (e *Encoder) encodeSpecialSw() bool {
 }
 
 func (e *Encoder) namesBeginningWithSwThatGetAltSv() bool {
-	return e.stringStart("SWANSON", "SWENSON", "SWINSON", "SWENSEN", "SWOBODA",
-		"SWIDERSKI", "SWARTHOUT", "SWEARENGIN")
+	return e.listStart(namesBeginningWithSwThatGetAltSvWords)
 }
 
 func (e *Encoder) namesBeginningWithSwThatGetAlvXV() bool {
-	return e.stringStart("SWART", "SWARTZ", "SWARTS", "SWIGER",
-		"SWITZER", "SWANGER", "SWIGERT", "SWIGART", "SWIHART",
-		"SWEITZER", "SWATZELL", "SWINDLER", "SWINEHART", "SWEARINGEN")
+	return e.listStart(namesBeginningWithSwThatGetAlvXVWords)
 }
 
 func (e *Encoder) encodeSj() bool {
@@ -2484,15 +2264,12 @@ func (e *Encoder) encodeSilentFrenchSFinal() bool {
 	}
 
 	if e.idx == e.lastIdx &&
-		((e.stringStart("YVES", "ARKANSAS", "FRANCAIS", "CRUDITES", "BRUYERES",
-			"DESCARTES", "DESCHUTES", "DESCHAMPS", "DESROCHES", "DESCHENES",
-			"RENDEZVOUS", "CONTRETEMPS", "DESLAURIERS") ||
+		((e.listStart(encodeSilentFrenchSFinalWords) ||
 			e.stringExact("HORS") ||
 			e.stringEnd("CAMUS", "YPRES",
 				"MESNES", "DEBRIS", "BLANCS", "INGRES", "CANNES",
 				"CHABLIS", "APROPOS", "JACQUES", "ELYSEES", "OEUVRES", "GEORGES", "DESPRES")) ||
 			(e.stringAt(-2, "AI", "OI", "UI") && !e.stringStart("LOIS", "LUIS"))) {
-
 		return true
 	}
 	return false
@@ -2500,19 +2277,24 @@ func (e *Encoder) encodeSilentFrenchSFinal() bool {
 
 func (e *Encoder) encodeSilentFrenchSInternal() bool {
 	// french words familiar to americans where internal s is silent
-	return e.stringAt(-2, "MESNES", "DESCHAM", "DESPRES", "DESROCH", "DESROSI", "DESJARD", "DESMARA",
-		"DESCHEN", "DESHOTE", "DESLAUR", "DESCARTES") ||
+	if e.listAt(-2, encodeSilentFrenchSInternalWords) ||
 		e.stringAt(-5, "DUQUESNE", "DUCHESNE") ||
 		e.stringAt(-3, "FRESNEL", "GROSVENOR") ||
 		e.stringAt(-4, "LOUISVILLE") ||
-		e.stringAt(-7, "BEAUCHESNE", "ILLINOISAN")
+		e.stringAt(-7, "BEAUCHESNE", "ILLINOISAN") {
+		return true
+	}
+	return false
 }
 
 func (e *Encoder) encodeIsl() bool {
 	// special cases 'island', 'isle', 'carlisle', 'carlysle'
-	return (e.stringAt(-2, "LISL", "LYSL", "AISL") &&
+	if (e.stringAt(-2, "LISL", "LYSL", "AISL") &&
 		!e.stringAt(-3, "PAISLEY", "BAISLEY", "ALISLAM", "ALISLAH", "ALISLAA")) ||
-		(e.idx == 1 && (e.stringAt(-1, "ISLE", "ISLAN") && !e.stringAt(-1, "ISLEY", "ISLER")))
+		(e.idx == 1 && (e.stringAt(-1, "ISLE", "ISLAN") && !e.stringAt(-1, "ISLEY", "ISLER"))) {
+		return true
+	}
+	return false
 }
 
 func (e *Encoder) encodeStl() bool {
@@ -2521,10 +2303,9 @@ func (e *Encoder) encodeStl() bool {
 		e.stringAt(-3, "THISTLY", "BRISTLY", "GRISTLY") ||
 		// e.g. "corpuscle"
 		e.stringAt(-1, "USCLE") {
-
 		// KRISTEN, KRYSTLE, CRYSTLE, KRISTLE all pronounce the 't'
 		// also, exceptions where "-LING" is a nominalizing suffix
-		if e.stringStart("KRISTEN", "KRYSTLE", "CRYSTLE", "KRISTLE", "CHRISTENSEN", "CHRISTENSON") ||
+		if e.listStart(encodeStlWords) ||
 			e.stringAt(-3, "FIRSTLING") ||
 			e.stringAt(-2, "NESTLING", "WESTLING") {
 			e.metaphAddStr("ST", "ST")
@@ -2610,8 +2391,7 @@ func (e *Encoder) encodeSh() bool {
 			(e.stringAtEnd(1, "HAP") ||
 				// e.g. "hartsheim", "clothshorse"
 				// e.g. "dishonor"
-				e.stringAt(1, "HEIM", "HOEK", "HOLM", "HOLZ", "HOOD", "HEAD", "HEID",
-					"HAAR", "HORS", "HOLE", "HUND", "HELM", "HAWK", "HILL", "HEART", "HATCH", "HOUSE", "HOUND", "HONOR") ||
+				e.listAt(1, encodeShWords) ||
 				// e.g. "mishear"
 				e.stringAtEnd(2, "EAR") ||
 				// e.g. "hartshorn"
@@ -2619,8 +2399,7 @@ func (e *Encoder) encodeSh() bool {
 				// e.g. "newshour" but not "bashour", "manshour"
 				(e.stringAt(1, "HOUR") && !e.stringStart("ASHOUR", "BASHOUR", "MANSHOUR")) ||
 				// e.g. "dishonest", "grasshopper"
-				e.stringAt(2, "ARMON", "ONEST", "ALLOW", "OLDER", "OPPER", "EIMER",
-					"ANDLE", "ONOUR", "ABILLE", "UMANCE", "ABITUA")) {
+				e.listAt(2, encodeShWords2)) {
 			if !e.stringAt(-1, "S") {
 				e.metaphAdd('S')
 			}
@@ -2642,7 +2421,6 @@ func (e *Encoder) encodeSch() bool {
 			// e.g. "mischief", "escheat"
 			(e.stringAt(3, "IEF", "EAT", "ANCE", "ARGE") ||
 				e.stringStart("ESCHEW")) {
-
 			e.metaphAdd('S')
 			return true
 		}
@@ -2650,7 +2428,7 @@ func (e *Encoder) encodeSch() bool {
 		// Schlesinger's rule
 		// dutch, danish, italian, greek origin, e.g. "school", "schooner", "schiavone",
 		// "schiz-"
-		if (e.stringAt(3, "OO", "ER", "EN", "UY", "ED", "EM", "IA", "IZ", "IS", "OL") &&
+		if (e.listAt(3, encodeSchWords) &&
 			!e.stringAt(0, "SCHOLT", "SCHISL", "SCHERR")) ||
 			e.stringAt(3, "ISZ") ||
 			(e.stringAt(-1, "ESCHAT", "ASCHIN", "ASCHAL", "ISCHAE", "ISCHIA") &&
@@ -2731,7 +2509,7 @@ func (e *Encoder) encodeSsio() bool {
 func (e *Encoder) encodeSs() bool {
 	// e.g. "russian", "pressure"
 	// e.g. "hessian", "assurance"
-	if e.stringAt(-1, "USSIA", "ESSUR", "ISSUR", "ISSUE", "ESSIAN", "ASSURE", "ASSURA", "ISSUAB", "ISSUAN", "ASSIUS") {
+	if e.listAt(-1, encodeSsWords) {
 		e.metaphAdd('X')
 		e.advanceCounter(2, 1)
 		return true
@@ -2750,7 +2528,6 @@ func (e *Encoder) encodeSia() bool {
 	// names generally get 'X' where terms, e.g. "aphasia" get 'J'
 	if (e.stringAtStart(-3, "ALESIA", "ALYSIA", "ALISIA", "STASIA") && !e.stringStart("ANASTASIA")) ||
 		e.stringAt(-5, "THERESIA", "DIONYSIAN") {
-
 		e.metaphAddAlt('X', 'S')
 		e.advanceCounter(2, 0)
 		return true
@@ -2759,10 +2536,7 @@ func (e *Encoder) encodeSia() bool {
 	if e.stringAtEnd(0, "SIA", "SIAN") || e.stringAt(-5, "AMBROSIAL") {
 		if (e.isVowelAt(-1) || e.stringAt(-1, "R")) &&
 			// exclude compounds based on names, or french or greek words
-			!(e.stringStart("JAMES", "NICOS", "PEGAS", "PEPYS",
-				"HOBBES", "HOLMES", "JAQUES", "KEYNES",
-				"MALTHUS", "HOMOOUS", "MAGLEMOS", "HOMOIOUS",
-				"LEVALLOIS", "TARDENOIS") || e.stringAt(-4, "ALGES")) {
+			!(e.listStart(encodeSiaWords) || e.stringAt(-4, "ALGES")) {
 
 			e.metaphAdd('J')
 		} else {
@@ -2856,7 +2630,6 @@ func (e *Encoder) encodeSeiSuiSier() bool {
 		e.stringAt(-2, "CASUI") ||
 		(e.stringAt(-1, "OSIER", "ASIER") &&
 			!(e.stringStart("OSIER", "EASIER") || e.stringAt(-2, "ROSIER", "MOSIER"))) {
-
 		e.metaphAddAlt('J', 'X')
 		e.advanceCounter(2, 0)
 		return true
@@ -2875,18 +2648,31 @@ func (e *Encoder) encodeSea() bool {
 	return false
 }
 
-func (e *Encoder) encodeT() {
-	if e.encodeTInitial() || e.encodeTch() || e.encodeSilentFrenchT() ||
-		e.encodeTunTulTuaTuo() || e.encodeTueTeuTeouTulTie() || e.encodeTurTiuSuffixes() ||
-		e.encodeTi() || e.encodeTient() || e.encodeTsch() || e.encodeTzsch() ||
-		e.encodeThPronouncedSeparately() || e.encodeTth() || e.encodeTh() {
-		return
-	}
+// rulesT are tried in order for a 'T', the first one that matches encodes it
+var rulesT = []rule{
+	{name: "encodeTInitial", do: (*Encoder).encodeTInitial},
+	{name: "encodeTch", do: (*Encoder).encodeTch},
+	{name: "encodeSilentFrenchT", do: (*Encoder).encodeSilentFrenchT},
+	{name: "encodeTunTulTuaTuo", do: (*Encoder).encodeTunTulTuaTuo},
+	{name: "encodeTueTeuTeouTulTie", do: (*Encoder).encodeTueTeuTeouTulTie},
+	{name: "encodeTurTiuSuffixes", do: (*Encoder).encodeTurTiuSuffixes},
+	{name: "encodeTi", do: (*Encoder).encodeTi},
+	{name: "encodeTient", do: (*Encoder).encodeTient},
+	{name: "encodeTsch", do: (*Encoder).encodeTsch},
+	{name: "encodeTzsch", do: (*Encoder).encodeTzsch},
+	{name: "encodeThPronouncedSeparately", do: (*Encoder).encodeThPronouncedSeparately},
+	{name: "encodeTth", do: (*Encoder).encodeTth},
+	{name: "encodeTh", do: (*Encoder).encodeTh},
+	{name: "encodeT", do: (*Encoder).encodeT},
+}
 
+// encodeT is the general handling of a 'T' when none of the special cases match
+func (e *Encoder) encodeT() bool {
 	if e.stringAt(1, "T", "D") {
 		e.idx++
 	}
 	e.metaphAdd('T')
+	return true
 }
 
 func (e *Encoder) encodeTInitial() bool {
@@ -2897,7 +2683,7 @@ func (e *Encoder) encodeTInitial() bool {
 		}
 
 		// old 'École française d'Extrême-Orient' chinese pinyin where 'ts-' => 'X'
-		if e.stringExact("TSO", "TSA", "TSU", "TSAO", "TSAI", "TSING", "TSANG") {
+		if e.listExact(encodeTInitialWords) {
 			e.metaphAdd('X')
 			e.advanceCounter(2, 1)
 			return true
@@ -2917,7 +2703,7 @@ func (e *Encoder) encodeTInitial() bool {
 			return true
 		}
 
-		if e.stringExact("THU") || e.stringAt(1, "HAI", "HUY", "HAO", "HYME", "HYMY", "HANH", "HERES") {
+		if e.stringExact("THU") || e.listAt(1, encodeTInitialWords2) {
 			e.metaphAdd('T')
 			e.advanceCounter(2, 1)
 			return true
@@ -2938,15 +2724,18 @@ func (e *Encoder) encodeTch() bool {
 
 func (e *Encoder) encodeSilentFrenchT() bool {
 	// french silent T familiar to americans
-	return (e.stringAtEnd(-4, "MONET", "GENET", "CHAUT") ||
+	if (e.stringAtEnd(-4, "MONET", "GENET", "CHAUT") ||
 		e.stringAt(-2, "POTPOURRI") ||
 		e.stringAt(-3, "MORTGAGE", "BOATSWAIN") ||
-		e.stringAt(-4, "BERET", "BIDET", "FILET", "DEBUT", "DEPOT", "PINOT", "TAROT") ||
-		e.stringAt(-5, "BALLET", "BUFFET", "CACHET", "CHALET", "ESPRIT", "RAGOUT", "GOULET", "CHABOT", "BENOIT") ||
-		e.stringAt(-6, "GOURMET", "BOUQUET", "CROCHET", "CROQUET", "PARFAIT", "PINCHOT", "CABARET", "PARQUET", "RAPPORT", "TOUCHET", "COURBET", "DIDEROT") ||
-		e.stringAt(-7, "ENTREPOT", "CABERNET", "DUBONNET", "MASSENET", "MUSCADET", "RICOCHET", "ESCARGOT") ||
+		e.listAt(-4, encodeSilentFrenchTWords) ||
+		e.listAt(-5, encodeSilentFrenchTWords2) ||
+		e.listAt(-6, encodeSilentFrenchTWords3) ||
+		e.listAt(-7, encodeSilentFrenchTWords4) ||
 		e.stringAt(-8, "SOBRIQUET", "CABRIOLET", "CASSOULET", "OUBRIQUET", "CAMEMBERT")) &&
-		!e.stringAt(1, "AN", "RY", "IC", "OM", "IN")
+		!e.stringAt(1, "AN", "RY", "IC", "OM", "IN") {
+		return true
+	}
+	return false
 }
 
 func (e *Encoder) encodeTunTulTuaTuo() bool {
@@ -2958,7 +2747,6 @@ func (e *Encoder) encodeTunTulTuaTuo() bool {
 		e.stringAt(-2, "BITUA", "BITUE") ||
 		// e.g. "actual"
 		(e.idx > 1 && e.stringAt(0, "TUA", "TUO")) {
-
 		e.metaphAddAlt('X', 'T')
 		return true
 	}
@@ -2977,7 +2765,6 @@ func (e *Encoder) encodeTueTeuTeouTulTie() bool {
 		e.stringAt(0, "TUENC") ||
 		// e.g. "patience"
 		e.stringAtEnd(0, "TIENCE") {
-
 		e.metaphAddAlt('X', 'T')
 		e.advanceCounter(1, 0)
 		return true
@@ -2988,12 +2775,11 @@ func (e *Encoder) encodeTueTeuTeouTulTie() bool {
 
 func (e *Encoder) encodeTurTiuSuffixes() bool {
 	// 'adventure', 'musculature'
-	if e.idx > 0 && e.stringAt(1, "URE", "URA", "URI", "URY", "URO", "IUS") {
+	if e.idx > 0 && e.listAt(1, encodeTurTiuSuffixesWords) {
 		// exceptions e.g. 'tessitura', mostly from romance languages
 		if (e.stringAtEnd(1, "URA", "URO") && !e.stringAt(-3, "VENTURA")) ||
 			// e.g. "kachaturian", "hematuria"
 			e.stringAt(1, "URIA") {
-
 			e.metaphAdd('T')
 		} else {
 			e.metaphAddAlt('X', 'T')
@@ -3022,7 +2808,6 @@ func (e *Encoder) encodeTi() bool {
 					e.stringAt(-5, "IZVESTIA"))) ||
 			e.stringAt(1, "IATE", "IATI", "IABL", "IATO", "IARY") ||
 			e.stringAt(-5, "CHRISTIAN")) {
-
 		if e.stringAtStart(-2, "ANTI") || e.stringStart("PATIO", "PITIA", "DUTIA") {
 			e.metaphAdd('T')
 		} else if e.stringAt(-4, "EQUATION") {
@@ -3057,7 +2842,6 @@ func (e *Encoder) encodeTsch() bool {
 	if e.stringAt(0, "TSCH") &&
 		// combining forms in german where the 'T' is pronounced seperately
 		!e.stringAt(-3, "WELT", "KLAT", "FEST") {
-
 		// pronounced the same as "ch" in "chit" => X
 		e.metaphAdd('X')
 		e.idx += 3
@@ -3078,17 +2862,15 @@ func (e *Encoder) encodeTzsch() bool {
 
 func (e *Encoder) encodeThPronouncedSeparately() bool {
 	// 'adulthood', 'bithead', 'apartheid'
-	if (e.idx > 0 && e.stringAt(1, "HOOD", "HEAD", "HEID", "HAND", "HILL", "HOLD", "HAWK", "HEAP", "HERD",
-		"HOLE", "HOOK", "HUNT", "HUMO", "HAUS", "HOFF", "HARD") && !e.stringAt(-3, "SOUTH", "NORTH")) ||
+	if (e.idx > 0 && e.listAt(1, encodeThPronouncedSeparatelyWords) && !e.stringAt(-3, "SOUTH", "NORTH")) ||
 		e.stringAt(1, "HOUSE", "HEART", "HASTE", "HYPNO", "HEQUE") ||
 		// watch out for greek root "-thallic"
 		(e.stringAtEnd(1, "HALL") && !e.stringAt(-3, "SOUTH", "NORTH")) ||
-		(e.stringAtEnd(1, "HAM") && !e.stringStart("GOTHAM", "WITHAM", "LATHAM", "BENTHAM", "WALTHAM", "WORTHAM", "GRANTHAM")) ||
+		(e.stringAtEnd(1, "HAM") && !e.listStart(encodeThPronouncedSeparatelyWords2)) ||
 		(e.stringAt(1, "HATCH") && !(e.idx == 0 || e.stringAt(-2, "UNTHATCH"))) ||
 		e.stringAt(-3, "GOETHE", "WARTHOG") ||
 		// and some special cases where "-TH-" is usually pronounced 'T'
 		e.stringAt(-2, "ESTHER", "NATHALIE") {
-
 		//special case
 		if e.stringAt(-3, "POSTHUM") {
 			e.metaphAdd('X')
@@ -3127,10 +2909,9 @@ func (e *Encoder) encodeTh() bool {
 		}
 
 		// special case "thomas", "thames", "beethoven" or germanic words
-		if e.stringAt(2, "OMAS", "OMPS", "OMPK", "OMSO", "OMSE", "AMES", "OVEN", "OFEN", "ILDA", "ILDE") ||
+		if e.listAt(2, encodeThWords) ||
 			e.stringExact("THOM", "THOMS") ||
 			e.stringStart("SCH", "VAN ", "VON ") {
-
 			e.metaphAdd('T')
 		} else {
 			// give an 'etymological' 2nd
@@ -3148,27 +2929,31 @@ func (e *Encoder) encodeTh() bool {
 	return false
 }
 
-func (e *Encoder) encodeV() {
-	if e.charNextIs('V') {
-		e.idx++
-	}
-	e.metaphAddExactApprox("V", "F")
+// rulesW are tried in order for a 'W', the first one that matches encodes it
+var rulesW = []rule{
+	{name: "encodeSilentWAtBeginning", do: (*Encoder).encodeSilentWAtBeginning},
+	{name: "encodeWitzWicz", do: (*Encoder).encodeWitzWicz},
+	{name: "encodeWr", do: (*Encoder).encodeWr},
+	{name: "encodeInitialWVowel", do: (*Encoder).encodeInitialWVowel},
+	{name: "encodeWh", do: (*Encoder).encodeWh},
+	{name: "encodeEasternEuropeanW", do: (*Encoder).encodeEasternEuropeanW},
+	{name: "encodeW", do: (*Encoder).encodeW},
 }
 
-func (e *Encoder) encodeW() {
-	if e.encodeSilentWAtBeginning() || e.encodeWitzWicz() || e.encodeWr() ||
-		e.encodeInitialWVowel() || e.encodeWh() || e.encodeEasternEuropeanW() {
-		return
-	}
-
+// encodeW is the general handling of a 'W' when none of the special cases match
+func (e *Encoder) encodeW() bool {
 	// e.g. 'zimbabwe'
 	if e.EncodeVowels && e.stringAtEnd(0, "WE") {
 		e.metaphAdd('A')
 	}
+	return true
 }
 
 func (e *Encoder) encodeSilentWAtBeginning() bool {
-	return e.stringAtStart(0, "WR")
+	if e.stringAtStart(0, "WR") {
+		return true
+	}
+	return false
 }
 
 func (e *Encoder) encodeWitzWicz() bool {
@@ -3225,15 +3010,14 @@ func (e *Encoder) encodeWh() bool {
 	if e.stringAt(0, "WH") {
 		// cases where it is pronounced as H
 		// e.g. 'who', 'whole'
-		if e.charAt(2, 'O') && !e.stringAt(2, "OA", "OP", "OOP", "OMP", "ORL", "ORT", "OOSH") {
+		if e.charAt(2, 'O') && !e.listAt(2, encodeWhWords) {
 			e.metaphAdd('H')
 			e.advanceCounter(2, 1)
 			return true
 		}
 
 		// combining forms, e.g. 'hollowhearted', 'rawhide'
-		if e.stringAt(2, "IDE", "ARD", "EAD", "AWK", "ERD", "OOK", "AND", "OLE", "OOD",
-			"EART", "OUSE", "OUND", "AMMER") {
+		if e.listAt(2, encodeWhWords2) {
 			e.metaphAdd('H')
 			e.idx++
 			return true
@@ -3258,7 +3042,6 @@ func (e *Encoder) encodeEasternEuropeanW() bool {
 		e.stringAt(-1, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
 		e.stringAtEnd(0, "WIAK", "WICKI", "WACKI") ||
 		e.stringStart("SCH") {
-
 		e.metaphAddExactApproxAlt("", "V", "", "F")
 		return true
 	}
@@ -3266,219 +3049,11 @@ func (e *Encoder) encodeEasternEuropeanW() bool {
 }
 
 func (e *Encoder) germanicOrSlavicNameBeginningWithW() bool {
-	return e.stringStart("WEE", "WIX", "WAX",
-		"WOLF", "WEIS", "WAHL", "WALZ", "WEIL", "WERT", "WINE", "WILK", "WALT", "WOLL",
-		"WADA", "WULF", "WEHR", "WURM", "WYSE", "WENZ", "WIRT", "WOLK", "WEIN", "WYSS", "WASS", "WANN",
-		"WINT", "WINK", "WILE", "WIKE", "WIER", "WELK", "WISE",
-		"WIRTH", "WIESE", "WITTE", "WENTZ", "WOLFF", "WENDT", "WERTZ", "WILKE", "WALTZ",
-		"WEISE", "WOOLF", "WERTH", "WEESE", "WURTH", "WINES", "WARGO", "WIMER", "WISER", "WAGER",
-		"WILLE", "WILDS", "WAGAR", "WERTS", "WITTY", "WIENS", "WIEBE", "WIRTZ", "WYMER", "WULFF",
-		"WIBLE", "WINER", "WIEST", "WALKO", "WALLA", "WEBRE", "WEYER", "WYBLE", "WOMAC", "WILTZ",
-		"WURST", "WOLAK", "WELKE", "WEDEL", "WEIST", "WYGAN", "WUEST", "WEISZ", "WALCK", "WEITZ",
-		"WYDRA", "WANDA", "WILMA", "WEBER",
-		"WETZEL", "WEINER", "WENZEL", "WESTER", "WALLEN", "WENGER", "WALLIN", "WEILER",
-		"WIMMER", "WEIMER", "WYRICK", "WEGNER", "WINNER", "WESSEL", "WILKIE", "WEIGEL", "WOJCIK",
-		"WENDEL", "WITTER", "WIENER", "WEISER", "WEXLER", "WACKER", "WISNER", "WITMER", "WINKLE",
-		"WELTER", "WIDMER", "WITTEN", "WINDLE", "WASHER", "WOLTER", "WILKEY", "WIDNER", "WARMAN",
-		"WEYANT", "WEIBEL", "WANNER", "WILKEN", "WILTSE", "WARNKE", "WALSER", "WEIKEL", "WESNER",
-		"WITZEL", "WROBEL", "WAGNON", "WINANS", "WENNER", "WOLKEN", "WILNER", "WYSONG", "WYCOFF",
-		"WUNDER", "WINKEL", "WIDMAN", "WELSCH", "WEHNER", "WEIGLE", "WETTER", "WUNSCH", "WHITTY",
-		"WAXMAN", "WILKER", "WILHAM", "WITTIG", "WITMAN", "WESTRA", "WEHRLE", "WASSER", "WILLER",
-		"WEGMAN", "WARFEL", "WYNTER", "WERNER", "WAGNER", "WISSER",
-		"WISEMAN", "WINKLER", "WILHELM", "WELLMAN", "WAMPLER", "WACHTER", "WALTHER",
-		"WYCKOFF", "WEIDNER", "WOZNIAK", "WEILAND", "WILFONG", "WIEGAND", "WILCHER", "WIELAND",
-		"WILDMAN", "WALDMAN", "WORTMAN", "WYSOCKI", "WEIDMAN", "WITTMAN", "WIDENER", "WOLFSON",
-		"WENDELL", "WEITZEL", "WILLMAN", "WALDRUP", "WALTMAN", "WALCZAK", "WEIGAND", "WESSELS",
-		"WIDEMAN", "WOLTERS", "WIREMAN", "WILHOIT", "WEGENER", "WOTRING", "WINGERT", "WIESNER",
-		"WAYMIRE", "WHETZEL", "WENTZEL", "WINEGAR", "WESTMAN", "WYNKOOP", "WALLICK", "WURSTER",
-		"WINBUSH", "WILBERT", "WALLACH", "WYNKOOP", "WALLICK", "WURSTER", "WINBUSH", "WILBERT",
-		"WALLACH", "WEISSER", "WEISNER", "WINDERS", "WILLMON", "WILLEMS", "WIERSMA", "WACHTEL",
-		"WARNICK", "WEIDLER", "WALTRIP", "WHETSEL", "WHELESS", "WELCHER", "WALBORN", "WILLSEY",
-		"WEINMAN", "WAGAMAN", "WOMMACK", "WINGLER", "WINKLES", "WIEDMAN", "WHITNER", "WOLFRAM",
-		"WARLICK", "WEEDMAN", "WHISMAN", "WINLAND", "WEESNER", "WARTHEN", "WETZLER", "WENDLER",
-		"WALLNER", "WOLBERT", "WITTMER", "WISHART", "WILLIAM",
-		"WESTPHAL", "WICKLUND", "WEISSMAN", "WESTLUND", "WOLFGANG", "WILLHITE", "WEISBERG",
-		"WALRAVEN", "WOLFGRAM", "WILHOITE", "WECHSLER", "WENDLING", "WESTBERG", "WENDLAND", "WININGER",
-		"WHISNANT", "WESTRICK", "WESTLING", "WESTBURY", "WEITZMAN", "WEHMEYER", "WEINMANN", "WISNESKI",
-		"WHELCHEL", "WEISHAAR", "WAGGENER", "WALDROUP", "WESTHOFF", "WIEDEMAN", "WASINGER", "WINBORNE",
-		"WHISENANT", "WEINSTEIN", "WESTERMAN", "WASSERMAN", "WITKOWSKI", "WEINTRAUB",
-		"WINKELMAN", "WINKFIELD", "WANAMAKER", "WIECZOREK", "WIECHMANN", "WOJTOWICZ", "WALKOWIAK",
-		"WEINSTOCK", "WILLEFORD", "WARKENTIN", "WEISINGER", "WINKLEMAN", "WILHEMINA",
-		"WISNIEWSKI", "WUNDERLICH", "WHISENHUNT", "WEINBERGER", "WROBLEWSKI", "WAGUESPACK",
-		"WEISGERBER", "WESTERVELT", "WESTERLUND", "WASILEWSKI", "WILDERMUTH", "WESTENDORF",
-		"WESOLOWSKI", "WEINGARTEN", "WINEBARGER", "WESTERBERG", "WANNAMAKER", "WEISSINGER",
-		"WALDSCHMIDT", "WEINGARTNER", "WINEBRENNER",
-		"WOLFENBARGER", "WOJCIECHOWSKI")
-}
-
-func (e *Encoder) encodeX() {
-	if e.encodeInitialX() || e.encodeGreekX() || e.encodeXSpecialCases() ||
-		e.encodeXToH() || e.encodeXVowel() || e.encodeFrenchXFinal() {
-		return
-	}
-
-	// eat redundant 'X' or other redundant cases
-	// e.g. "excite", "exceed"
-	if e.stringAt(1, "X", "Z", "S", "CI", "CE") {
-		e.idx++
-	}
-}
-
-func (e *Encoder) encodeInitialX() bool {
-	// current chinese pinyin spelling
-	if e.stringStart("XU", "XIA", "XIO", "XIE") {
-		e.metaphAdd('X')
-		return true
-	}
-
-	if e.idx == 0 {
-		e.metaphAdd('S')
-		return true
-	}
-
-	return false
-}
-
-// 'xylophone', xylem', 'xanthoma', 'xeno-'
-func (e *Encoder) encodeGreekX() bool {
-	if e.stringAt(1, "YLO", "YLE", "ENO", "ANTH") {
-		e.metaphAdd('S')
-		return true
-	}
-	return false
-}
-
-//Encode special cases, "LUXUR-", "Texeira"
-func (e *Encoder) encodeXSpecialCases() bool {
-	if e.stringAt(-2, "LUXUR") {
-		e.metaphAddExactApprox("GJ", "KJ")
-		return true
-	}
-
-	if e.stringStart("TEXEIRA", "TEIXEIRA") {
-		e.metaphAdd('X')
-		return true
-	}
-	return false
-}
-
-//Encode special case where americans know the proper mexican indian
-//pronounciation of this name
-func (e *Encoder) encodeXToH() bool {
-	if e.stringAt(-2, "OAXACA") || e.stringAt(-3, "QUIXOTE") {
-		e.metaphAdd('H')
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeXVowel() bool {
-	// e.g. "sexual", "connexion" (british), "noxious"
-	if e.stringAt(1, "UAL", "ION", "IOU") {
-		e.metaphAddStr("KX", "KS")
-		e.advanceCounter(2, 0)
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeFrenchXFinal() bool {
-	if !(e.idx == e.lastIdx && (e.stringAt(-3, "IAU", "EAU", "IEU") ||
-		e.stringAt(-2, "AI", "AU", "OU", "OI", "EU"))) {
-
-		e.metaphAddStr("KS", "KS")
-		//no return true?
-	}
-	return false
-}
-
-func (e *Encoder) encodeZ() {
-	if e.encodeZz() || e.encodeZuZierZs() || e.encodeFrenchEz() || e.encodeGermanZ() || e.encodeZh() {
-		return
-	}
-
-	e.metaphAdd('S')
-
-	// eat redundant 'Z'
-	if e.charNextIs('Z') {
-		e.idx++
-	}
-}
-
-//Encode cases of "-ZZ-" where it is obviously part of an italian word where
-//"-ZZ-" is pronounced as TS
-func (e *Encoder) encodeZz() bool {
-	// "abruzzi", 'pizza'
-	if e.charNextIs('Z') &&
-		(e.stringAtEnd(2, "I", "O", "A") || e.stringAt(-2, "MOZZARELL", "PIZZICATO", "PUZZONLAN")) {
-
-		e.metaphAddStr("TS", "S")
-		e.idx++
-		return true
-	}
-	return false
-}
-
-func (e *Encoder) encodeZuZierZs() bool {
-	if (e.idx == 1 && e.stringAt(-1, "AZUR")) ||
-		(e.stringAt(0, "ZIER") && !e.stringAt(-2, "VIZIER")) ||
-		e.stringAt(0, "ZSA") {
-
-		e.metaphAddAlt('J', 'S')
-
-		if e.stringAt(0, "ZSA") {
-			e.idx++
-		}
-		return true
-	}
-	return false
-}
-
-//Encode cases where americans recognize "-EZ" as part of a french word where Z
-//not pronounced
-func (e *Encoder) encodeFrenchEz() bool {
-	if (e.idx == 3 && e.stringAt(-3, "CHEZ")) ||
-		e.stringAt(-5, "RENDEZ") {
-		return true
-	}
-
-	return false
-}
-
-//Encode cases where "-Z-" is in a german word where Z => TS in german
-func (e *Encoder) encodeGermanZ() bool {
-	if e.stringExact("NAZI") ||
-		e.stringAt(-2, "NAZIFY", "MOZART") ||
-		e.stringAt(-3, "HOLZ", "HERZ", "MERZ", "FITZ", "HERZOG") ||
-		(e.stringAt(-3, "GANZ") && !e.isVowelAt(1)) ||
-		e.stringAt(-4, "STOLZ", "PRINZ", "VENEZIA") ||
-		// german words containing with "sch" but not schlimazel, schmooze
-		(e.stringContains("SCH") && !e.stringEnd("IZE", "OZE", "ZEL")) ||
-		(e.idx > 0 && e.stringAt(0, "ZEIT")) ||
-		e.stringAt(-3, "WEIZ") {
-
-		if e.idx > 0 && e.charAt(-1, 'T') {
-			e.metaphAdd('S')
-		} else {
-			e.metaphAddStr("TS", "TS")
-		}
-		return true
-	}
-
-	return false
-}
-
-func (e *Encoder) encodeZh() bool {
-	// chinese pinyin e.g. 'zhao', also english "phonetic spelling"
-	if e.charNextIs('H') {
-		e.metaphAdd('J')
-		e.idx++
-		return true
-	}
-	return false
+	return e.listStart(germanicOrSlavicNameBeginningWithWWords)
 }
 
 func (e *Encoder) encodeVowels() {
+	e.fired("encodeVowels")
 
 	if e.idx == 0 {
 		// all init vowels map to 'A'
@@ -3509,13 +3084,13 @@ func (e *Encoder) encodeVowels() {
 func (e *Encoder) encodeSkipSilentUe() bool {
 	// always silent except for cases listed below
 	if (e.stringAt(-1, "QUE", "GUE") &&
-		!e.stringStart("RISQUE", "PIROGUE", "ENRIQUE", "BARBEQUE", "PALENQUE", "APPLIQUE", "COMMUNIQUE") &&
+		!e.listStart(encodeSkipSilentUeWords) &&
 		!e.stringAt(-3, "ARGUE", "SEGUE")) &&
 		e.idx > 1 &&
 		((e.idx+1 == e.lastIdx) || e.stringStart("JACQUES")) {
 
 		e.idx = e.skipVowels(e.idx)
-		return true
+		return e.fired("encodeSkipSilentUe")
 	}
 	return false
 }
@@ -3524,6 +3099,7 @@ func (e *Encoder) encodeSkipSilentUe() bool {
 // care to detect unusual cases from the greek.
 // Only executed if non initial vowel encoding is turned on
 func (e *Encoder) encodeEPronounced() {
+	e.fired("encodeEPronounced")
 	// special cases with two pronunciations
 	// 'agape' 'lame' 'resume'
 	if e.stringExact("LAME", "SAKE", "PATE", "AGAPE") ||
@@ -3563,7 +3139,7 @@ func (e *Encoder) encodeOSilent() bool {
 	// if "iron" at beginning or end of word and not "irony"
 	if e.charAt(0, 'O') && e.stringAt(-2, "IRON") {
 		if (e.stringStart("IRON") || e.stringAtEnd(-2, "IRON")) && !e.stringAt(-2, "IRONIC") {
-			return true
+			return e.fired("encodeOSilent")
 		}
 	}
 
@@ -3583,14 +3159,12 @@ func (e *Encoder) encodeESilent() bool {
 		(e.idx > 1 && e.idx+1 == e.lastIdx && e.stringAt(1, "S", "D") &&
 			// and not e.g. "nested", "rises", or "pieces" => RASAS
 			!(e.stringAt(-1, "TED", "SES", "CES") ||
-				e.stringStart("ABED", "IMED", "JARED", "AHMED", "HAMED", "JAVED",
-					"NORRED", "MEDVED", "MERCED", "ALLRED", "KHALED", "RASHED", "MASJED",
-					"MOHAMED", "MOHAMMED", "MUHAMMED", "MOUHAMED", "ANTIPODES", "ANOPHELES"))) ||
+				e.listStart(encodeESilentWords))) ||
 		// e.g.  'wholeness', 'boneless', 'barely'
 		e.stringAtEnd(1, "NESS", "LESS") ||
 		(e.stringAtEnd(1, "LY") && !e.stringStart("CICELY")) {
 
-		return true
+		return e.fired("encodeESilent")
 	}
 	return false
 }
@@ -3613,26 +3187,12 @@ func (e *Encoder) encodeEPronouncedAtEnd() bool {
 			len(e.in) == 2 ||
 			(len(e.in) == 3 && !e.isVowelAt(-e.idx)) ||
 			// these german name endings can be relied on to have the 'e' pronounced
-			(e.stringAtEnd(-2, "BKE", "DKE", "FKE", "KKE", "LKE", "NKE", "MKE", "PKE", "TKE", "VKE", "ZKE") &&
+			(e.listAtEnd(-2, encodeEPronouncedAtEndWords2) &&
 				!e.stringStart("FINKE", "FUNKE", "FRANKE")) ||
 			e.stringAtEnd(-4, "SCHKE") ||
-			e.stringExact("ACME", "NIKE", "CAFE", "RENE", "LUPE", "JOSE", "ESME",
-				"LETHE", "CADRE", "TILDE", "SIGNE", "POSSE", "LATTE", "ANIME", "DOLCE", "CROCE",
-				"ADOBE", "OUTRE", "JESSE", "JAIME", "JAFFE", "BENGE", "RUNGE",
-				"CHILE", "DESME", "CONDE", "URIBE", "LIBRE", "ANDRE",
-				"HECATE", "PSYCHE", "DAPHNE", "PENSKE", "CLICHE", "RECIPE",
-				"TAMALE", "SESAME", "SIMILE", "FINALE", "KARATE", "RENATE", "SHANTE",
-				"OBERLE", "COYOTE", "KRESGE", "STONGE", "STANGE", "SWAYZE", "FUENTE",
-				"SALOME", "URRIBE",
-				"ECHIDNE", "ARIADNE", "MEINEKE", "PORSCHE", "ANEMONE", "EPITOME",
-				"SYNCOPE", "SOUFFLE", "ATTACHE", "MACHETE", "KARAOKE", "BUKKAKE",
-				"VICENTE", "ELLERBE", "VERSACE",
-				"PENELOPE", "CALLIOPE", "CHIPOTLE", "ANTIGONE", "KAMIKAZE", "EURIDICE",
-				"YOSEMITE", "FERRANTE",
-				"HYPERBOLE", "GUACAMOLE", "XANTHIPPE",
-				"SYNECDOCHE")) {
+			e.listExact(encodeEPronouncedAtEndWords)) {
 
-		return true
+		return e.fired("encodeEPronouncedAtEnd")
 	}
 
 	return false
@@ -3641,17 +3201,11 @@ func (e *Encoder) encodeEPronouncedAtEnd() bool {
 func (e *Encoder) encodeSilentInternalE() bool {
 	// 'olesen' but not 'olen'	RAKE BLAKE
 	if (e.stringStart("OLE") && e.encodeESuffix(3)) ||
-		(e.stringStart("BARE", "FIRE", "FORE", "GATE", "HAGE", "HAVE",
-			"HAZE", "HOLE", "CAPE", "HUSE", "LACE", "LINE",
-			"LIVE", "LOVE", "MORE", "MOSE", "MORE", "NICE",
-			"RAKE", "ROBE", "ROSE", "SISE", "SIZE", "WARE",
-			"WAKE", "WISE", "WINE") && e.encodeESuffix(4)) ||
-		(e.stringStart("BLAKE", "BRAKE", "BRINE", "CARLE", "CLEVE", "DUNNE",
-			"HEDGE", "HOUSE", "JEFFE", "LUNCE", "STOKE", "STONE",
-			"THORE", "WEDGE", "WHITE") && e.encodeESuffix(5)) ||
+		(e.listStart(encodeSilentInternalEWords) && e.encodeESuffix(4)) ||
+		(e.listStart(encodeSilentInternalEWords2) && e.encodeESuffix(5)) ||
 		(e.stringStart("BRIDGE", "CHEESE") && e.encodeESuffix(6)) ||
 		(e.stringAt(-5, "CHARLES")) {
-		return true
+		return e.fired("encodeSilentInternalE")
 	}
 
 	return false
@@ -3671,13 +3225,11 @@ func (e *Encoder) encodeESuffix(at int) bool {
 		// e.g. 'bridgette'
 		// e.g. 'olena'
 		// e.g. 'bridget'
-		if e.stringAtEnd(-e.idx+at, "T", "R", "TA", "TT", "NA", "NO", "NE",
-			"RS", "RE", "LA", "AU", "RO", "RA", "TTE", "LIA", "NOW", "ROS", "RAS",
-			"WOOD", "WATER", "WORTH") {
+		if e.listAtEnd(-e.idx+at, encodeESuffixWords) {
 			return false
 		}
 
-		return true
+		return e.fired("encodeESuffix")
 	}
 
 	return false
@@ -3691,28 +3243,13 @@ func (e *Encoder) encodeEPronouncedExceptions() bool {
 	// greek names e.g. "herakles" or hispanic names e.g. "robles", where 'e' is pronounced, other exceptions
 	if (e.idx+1 == e.lastIdx &&
 		(e.stringAtEnd(-3, "OCLES", "ACLES", "AKLES") ||
-			e.stringStart("INES",
-				"LOPES", "ESTES", "GOMES", "NUNES", "ALVES", "ICKES",
-				"INNES", "PERES", "WAGES", "NEVES", "BENES", "DONES",
-				"CORTES", "CHAVES", "VALDES", "ROBLES", "TORRES", "FLORES", "BORGES",
-				"NIEVES", "MONTES", "SOARES", "VALLES", "GEDDES", "ANDRES", "VIAJES",
-				"CALLES", "FONTES", "HERMES", "ACEVES", "BATRES", "MATHES",
-				"DELORES", "MORALES", "DOLORES", "ANGELES", "ROSALES", "MIRELES", "LINARES",
-				"PERALES", "PAREDES", "BRIONES", "SANCHES", "CAZARES", "REVELES", "ESTEVES",
-				"ALVARES", "MATTHES", "SOLARES", "CASARES", "CACERES", "STURGES", "RAMIRES",
-				"FUNCHES", "BENITES", "FUENTES", "PUENTES", "TABARES", "HENTGES", "VALORES",
-				"GONZALES", "MERCEDES", "FAGUNDES", "JOHANNES", "GONSALES", "BERMUDES",
-				"CESPEDES", "BETANCES", "TERRONES", "DIOGENES", "CORRALES", "CABRALES",
-				"MARTINES", "GRAJALES",
-				"CERVANTES", "FERNANDES", "GONCALVES", "BENEVIDES", "CIFUENTES", "SIFUENTES",
-				"SERVANTES", "HERNANDES", "BENAVIDES",
-				"ARCHIMEDES", "CARRIZALES", "MAGALLANES"))) ||
+			e.listStart(encodeEPronouncedExceptionsWords))) ||
 		e.stringAt(-2, "FRED", "DGES", "DRED", "GNES") ||
 		e.stringAt(-5, "PROBLEM", "RESPLEN") ||
 		e.stringAt(-4, "REPLEN") ||
 		e.stringAt(-3, "SPLE") {
 
-		return true
+		return e.fired("encodeEPronouncedExceptions")
 	}
 
 	return false
@@ -3815,7 +3352,7 @@ func rootOrInflections(inWord []rune, root string) bool {
 }
 
 func (e *Encoder) isSlavoGermanic() bool {
-	return e.stringStart("SCH", "SW") || e.in[0] == 'J' || e.in[0] == 'W'
+	return e.stringStart("SW", "SCH") || e.in[0] == 'J' || e.in[0] == 'W'
 }
 
 func (e *Encoder) charNextIs(c rune) bool {
@@ -3833,7 +3370,7 @@ func (e *Encoder) isVowelAt(offset int) bool {
 
 func (e *Encoder) charAt(offset int, c rune) bool {
 	idx := e.idx + offset
-	if idx >= len(e.in) {
+	if idx < 0 || idx >= len(e.in) {
 		return false
 	}
 
@@ -3874,14 +3411,13 @@ nextVal:
 			continue nextVal
 		}
 
-		// each letter of the value given
-		i := 0
-		for _, c := range v {
-			if c != e.in[start+i] {
+		// each letter of the value given, vals are ascii so we can go byte-by-byte
+		in := e.in[start:]
+		for i := 0; i < len(v); i++ {
+			if rune(v[i]) != in[i] {
 				// char mis-match, this word is done
 				continue nextVal
 			}
-			i++
 		}
 
 		// if we make it here we matched all letters
@@ -3913,14 +3449,13 @@ nextVal:
 			return false
 		}
 
-		// each letter of the value given
-		i := 0
-		for _, c := range v {
-			if c != e.in[start+i] {
+		// each letter of the value given, vals are ascii so we can go byte-by-byte
+		in := e.in[start : start+len(v)]
+		for i := 0; i < len(v); i++ {
+			if rune(v[i]) != in[i] {
 				// char mis-match, this word is done
 				continue nextVal
 			}
-			i++
 		}
 
 		// if we make it here we matched all letters
@@ -4037,6 +3572,16 @@ func (e *Encoder) metaphAdd(in rune) {
 
 // Adds given encoding characters to the associated encoded strings
 func (e *Encoder) metaphAddAlt(prim, second rune) {
+	e.metaphAddAltWeight(prim, second, altEven)
+}
+
+// Adds given encoding characters to the associated encoded strings, weight is the
+// likelihood of the secondary relative to the primary
+func (e *Encoder) metaphAddAltWeight(prim, second rune, weight float64) {
+	if e.trackSegments {
+		e.addSegment(runeStr(prim), runeStr(second), weight)
+	}
+
 	if prim != unicode.ReplacementChar {
 		// don't dupe added A's
 		if !(prim == 'A' && len(e.primBuf) > 0 && e.primBuf[len(e.primBuf)-1] == 'A') {
@@ -4060,6 +3605,16 @@ func (e *Encoder) metaphAddAlt(prim, second rune) {
 
 // Adds given strings to the associated encoded strings
 func (e *Encoder) metaphAddStr(prim, second string) {
+	e.metaphAddStrWeight(prim, second, altEven)
+}
+
+// Adds given strings to the associated encoded strings, weight is the
+// likelihood of the secondary relative to the primary
+func (e *Encoder) metaphAddStrWeight(prim, second string, weight float64) {
+	if e.trackSegments {
+		e.addSegment(prim, second, weight)
+	}
+
 	// don't dupe added A's
 	if !(prim == "A" && len(e.primBuf) > 0 && e.primBuf[len(e.primBuf)-1] == 'A') {
 		if debug {
@@ -4093,9 +3648,12 @@ func (e *Encoder) metaphAddExactApprox(exact, main string) {
 	}
 }
 
+// skipVowels moves past the run of vowels (and W) starting at the given index and
+// returns the index the main loop should continue after.  It never returns an index
+// before the current one.
 func (e *Encoder) skipVowels(at int) int {
-	if at < 0 {
-		return 0
+	if at < e.idx {
+		at = e.idx
 	}
 	if at >= len(e.in) {
 		return len(e.in)
@@ -4115,8 +3673,7 @@ func (e *Encoder) skipVowels(at int) int {
 		off++
 		if e.charAt(off-1, 'W') &&
 			e.charAt(off, 'H') &&
-			!e.stringAt(off, "HOP", "HIDE", "HARD", "HEAD", "HAWK", "HERD", "HOOK", "HAND", "HOLE",
-				"HEART", "HOUSE", "HOUND", "HAMMER") {
+			!e.listAt(off, skipVowelsWords) {
 
 			off++
 		}
@@ -4128,8 +3685,9 @@ func (e *Encoder) skipVowels(at int) int {
 		it = e.in[e.idx+off]
 	}
 
+	// never move backward, the main loop always makes progress
 	if off < 1 {
-		panic("bug: skipping vowels moving backward")
+		return e.idx
 	}
 
 	return e.idx + off - 1
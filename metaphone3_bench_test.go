@@ -0,0 +1,56 @@
+package metaphone3
+
+import (
+	"bufio"
+	"os"
+	"testing"
+)
+
+func loadWords(b testing.TB, file string) []string {
+	f, err := os.Open(file)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		b.Fatal(err)
+	}
+	return words
+}
+
+func benchmarkEncode(b *testing.B, e *Encoder) {
+	words := loadWords(b, "testdata/count_1w.txt")
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		e.Encode(words[i%len(words)])
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	benchmarkEncode(b, &Encoder{})
+}
+
+func BenchmarkEncode_Vowels(b *testing.B) {
+	benchmarkEncode(b, &Encoder{EncodeVowels: true})
+}
+
+func BenchmarkEncode_Exact(b *testing.B) {
+	benchmarkEncode(b, &Encoder{EncodeExact: true})
+}
+
+func BenchmarkEncode_Names(b *testing.B) {
+	words := loadWords(b, "testdata/surnames-us.txt")
+	e := &Encoder{}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		e.Encode(words[i%len(words)])
+	}
+}
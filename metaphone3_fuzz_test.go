@@ -0,0 +1,135 @@
+package metaphone3
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+// fuzzSeeds are words that exercise the lookbehind and lookahead of the rules,
+// and odd input like lone letters, runs of vowels and non-letters
+var fuzzSeeds = []string{
+	"Smith", "Schmidt", "Villafranca", "Jujuy", "Macias", "supernode", "Robillard",
+	"Wewiorka", "Whitehead", "Ewski", "Owsky", "Wicz", "Aaaaaa", "W", "WH", "AW", "EWH",
+	"Gh", "Ch", "Mc", "X", "Jose", "Tchaikovsky", "Caesar", "ach", "ßø", "İ", "123",
+	"a-b c", "\x00", "\xff\xfe", "ÇÑÖ", "WWWWWW", "HHHH", "AEIOUYW", "Abramovich",
+}
+
+// fuzz flags for the options that aren't numbers
+const (
+	fuzzFullLength = 1 << iota
+	fuzzExceptions
+	// also add the input as a supplement and its start as a prefix entry
+	fuzzInputExceptions
+)
+
+// fuzzExceptionsFor returns a small dictionary with word, prefix and supplement entries
+func fuzzExceptionsFor(in string, flags uint8) *Exceptions {
+	x := NewExceptions()
+	x.AddWord("siobhan", Exception{Primary: "XFN"})
+	x.AddWord("nguyen", Exception{Primary: "NKN", Secondary: "NWN", Supplement: true})
+	x.AddPrefix("mc", Exception{Primary: "MK"})
+	x.AddPrefix("macd", Exception{Primary: "MKT", Secondary: "MT"})
+	if flags&fuzzInputExceptions != 0 {
+		x.AddWord(in, Exception{Primary: "SPL", Secondary: "SPLM", Supplement: true})
+		if r := []rune(in); len(r) > 2 {
+			x.AddPrefix(string(r[:2]), Exception{Primary: "PR"})
+		}
+	}
+	return x
+}
+
+func FuzzEncode(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s, 0, uint8(InputSkip), uint8(0))
+		f.Add(s, 3, uint8(InputSeparate), uint8(fuzzExceptions))
+		f.Add(s, 5, uint8(InputStrip), uint8(fuzzFullLength|fuzzExceptions|fuzzInputExceptions))
+	}
+
+	f.Fuzz(func(t *testing.T, in string, maxLength int, policy, flags uint8) {
+		var x *Exceptions
+		if flags&fuzzExceptions != 0 {
+			x = fuzzExceptionsFor(in, flags)
+		}
+		for _, vowels := range []bool{false, true} {
+			for _, exact := range []bool{false, true} {
+				e := &Encoder{EncodeVowels: vowels, EncodeExact: exact, MaxLength: maxLength % 32, InputPolicy: InputPolicy(policy % 5),
+					FullLength: flags&fuzzFullLength != 0, Exceptions: x}
+				limit := limitOf(e)
+				if e.FullLength {
+					limit = int(^uint(0) >> 1)
+				}
+				prim, sec := e.Encode(in)
+
+				if n := utf8.RuneCountInString(prim); n > limit {
+					t.Fatalf("%q vowels=%v exact=%v: primary %q is longer than %v", in, vowels, exact, prim, limit)
+				}
+				if n := utf8.RuneCountInString(sec); n > limit {
+					t.Fatalf("%q vowels=%v exact=%v: secondary %q is longer than %v", in, vowels, exact, sec, limit)
+				}
+				if sec != "" && sec == prim {
+					t.Fatalf("%q vowels=%v exact=%v: secondary is the same as the primary %q", in, vowels, exact, prim)
+				}
+
+				// the encoder is reused so it must not carry state between words
+				if p2, s2 := e.Encode(in); p2 != prim || s2 != sec {
+					t.Fatalf("%q vowels=%v exact=%v: encoded to %v/%v then %v/%v", in, vowels, exact, prim, sec, p2, s2)
+				}
+
+				all := e.EncodeAll(in)
+				if prim != "" && (len(all) == 0 || all[0] != prim) {
+					t.Fatalf("%q vowels=%v exact=%v: EncodeAll %v doesn't start with the primary %q", in, vowels, exact, all, prim)
+				}
+
+				// a full length key cut down is the key for the max length, except that a supplement
+				// can be the secondary where the keys are the same at the max length, see Key.Truncate
+				if e.FullLength {
+					short := *e
+					short.FullLength = false
+					k := e.EncodeKey(in).Truncate(limitOf(&short))
+					p, s := short.Encode(in)
+					supplemented := k.Secondary == "" && short.supplement != nil &&
+						s == truncateRunes(short.supplement.Primary, limitOf(&short))
+					if k.Primary != p || (k.Secondary != s && !supplemented) {
+						t.Fatalf("%q vowels=%v exact=%v: full length key cut to %v is %v/%v, wanted %v/%v", in, vowels, exact,
+							limitOf(&short), k.Primary, k.Secondary, p, s)
+					}
+				}
+			}
+		}
+	})
+}
+
+// limitOf is the longest key the encoder returns without FullLength
+func limitOf(e *Encoder) int {
+	if e.MaxLength <= 0 {
+		return DefaultMaxLength
+	}
+	return e.MaxLength
+}
+
+func TestTryEncode(t *testing.T) {
+	e := &Encoder{}
+	for _, s := range fuzzSeeds {
+		wantPrim, wantSec := e.Encode(s)
+		prim, sec, err := e.TryEncode(s)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", s, err)
+		}
+		if prim != wantPrim || sec != wantSec {
+			t.Fatalf("%q: wanted %v/%v, got %v/%v", s, wantPrim, wantSec, prim, sec)
+		}
+	}
+
+	// corrupt the exceptions so that encoding panics
+	e.Exceptions = &Exceptions{prefixLens: []int{-1}}
+	prim, sec, err := e.TryEncode("Smith")
+	if !errors.Is(err, ErrInternal) {
+		t.Fatalf("wanted ErrInternal, got %v (%v/%v)", err, prim, sec)
+	}
+
+	e.Exceptions = nil
+	if prim, _, err := e.TryEncode("Smith"); err != nil || prim != "SM0" {
+		t.Fatalf("wanted the encoder to work after an error, got %v %v", prim, err)
+	}
+}
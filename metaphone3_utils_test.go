@@ -41,6 +41,32 @@ func TestRootOrInflections_Ache(t *testing.T) {
 	}
 }
 
+func TestCharAt_Bounds(t *testing.T) {
+	e := &Encoder{in: []rune("AB"), idx: 0}
+	if e.charAt(-1, 'A') || e.charAt(2, 'A') {
+		t.Fatal("charAt error, wanted false outside the input")
+	}
+	if want, got := true, e.charAt(1, 'B'); want != got {
+		t.Fatalf("charAt error, wanted %v got %v", want, got)
+	}
+}
+
+func TestSkipVowels_NeverBackward(t *testing.T) {
+	e := &Encoder{in: []rune("BAAB"), idx: 3, lastIdx: 3}
+	// the current rune isn't a vowel, so there's nothing to skip
+	if want, got := 3, e.skipVowels(3); want != got {
+		t.Fatalf("skipVowels error, wanted %v got %v", want, got)
+	}
+	if want, got := 3, e.skipVowels(1); want != got {
+		t.Fatalf("skipVowels error, wanted %v got %v", want, got)
+	}
+
+	e.idx = 0
+	if want, got := 2, e.skipVowels(1); want != got {
+		t.Fatalf("skipVowels error, wanted %v got %v", want, got)
+	}
+}
+
 func testStringAt(in string, curIdx, offset int, vals ...string) bool {
 	e := &Encoder{}
 	e.in = []rune(in)
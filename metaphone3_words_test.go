@@ -29,6 +29,27 @@ func TestBasicWords(t *testing.T) {
 	}
 }
 
+// TestJujuy checks a deliberate difference from the Java version, which never matches
+// "UJUY", see testdata/java-deviations.csv
+func TestJujuy(t *testing.T) {
+	vals := []struct {
+		vowels, exact bool
+		prim          string
+	}{
+		{false, false, "HH"},
+		{true, false, "HAHA"},
+		{false, true, "HH"},
+		{true, true, "HAHA"},
+	}
+
+	for _, v := range vals {
+		e := &Encoder{EncodeVowels: v.vowels, EncodeExact: v.exact}
+		if prim, sec := e.Encode("Jujuy"); prim != v.prim || sec != "" {
+			t.Errorf("vowels=%v exact=%v: wanted %v, got %v %v", v.vowels, v.exact, v.prim, prim, sec)
+		}
+	}
+}
+
 func TestHarness(t *testing.T) {
 	debug = false
 	e := &Encoder{
@@ -0,0 +1,5 @@
+// Package metaphone3pb has the protobuf messages and gRPC stubs generated from
+// proto/metaphone3/v1/metaphone3.proto.  The service is implemented by the grpcserver package.
+package metaphone3pb
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/dlclark/metaphone3 --go-grpc_out=.. --go-grpc_opt=module=github.com/dlclark/metaphone3 metaphone3/v1/metaphone3.proto
@@ -0,0 +1,620 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: metaphone3/v1/metaphone3.proto
+
+package metaphone3pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MatchType int32
+
+const (
+	MatchType_MATCH_TYPE_UNSPECIFIED MatchType = 0
+	// The primary keys match.
+	MatchType_MATCH_TYPE_PRIMARY MatchType = 1
+	// A match that involves at least one secondary key.
+	MatchType_MATCH_TYPE_SECONDARY MatchType = 2
+)
+
+// Enum value maps for MatchType.
+var (
+	MatchType_name = map[int32]string{
+		0: "MATCH_TYPE_UNSPECIFIED",
+		1: "MATCH_TYPE_PRIMARY",
+		2: "MATCH_TYPE_SECONDARY",
+	}
+	MatchType_value = map[string]int32{
+		"MATCH_TYPE_UNSPECIFIED": 0,
+		"MATCH_TYPE_PRIMARY":     1,
+		"MATCH_TYPE_SECONDARY":   2,
+	}
+)
+
+func (x MatchType) Enum() *MatchType {
+	p := new(MatchType)
+	*p = x
+	return p
+}
+
+func (x MatchType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchType) Descriptor() protoreflect.EnumDescriptor {
+	return file_metaphone3_v1_metaphone3_proto_enumTypes[0].Descriptor()
+}
+
+func (MatchType) Type() protoreflect.EnumType {
+	return &file_metaphone3_v1_metaphone3_proto_enumTypes[0]
+}
+
+func (x MatchType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchType.Descriptor instead.
+func (MatchType) EnumDescriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{0}
+}
+
+// Options are the encoder options, they have the same meaning as the fields of metaphone3.Encoder.
+type Options struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Encode non-initial vowels.
+	EncodeVowels bool `protobuf:"varint,1,opt,name=encode_vowels,json=encodeVowels,proto3" json:"encode_vowels,omitempty"`
+	// Encode consonants as exactly as possible.
+	EncodeExact bool `protobuf:"varint,2,opt,name=encode_exact,json=encodeExact,proto3" json:"encode_exact,omitempty"`
+	// Max length of the keys, 0 for the default of 8.  Must be between 0 and 64.
+	MaxLength int32 `protobuf:"varint,3,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	// Encode the whole value and ignore max_length.
+	FullLength bool `protobuf:"varint,4,opt,name=full_length,json=fullLength,proto3" json:"full_length,omitempty"`
+	// What to do with characters that aren't letters: skip (the default), strip, separate,
+	// digits or reject.  Values rejected by reject have an INVALID_ARGUMENT error.
+	Input         string `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Options) GetEncodeVowels() bool {
+	if x != nil {
+		return x.EncodeVowels
+	}
+	return false
+}
+
+func (x *Options) GetEncodeExact() bool {
+	if x != nil {
+		return x.EncodeExact
+	}
+	return false
+}
+
+func (x *Options) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *Options) GetFullLength() bool {
+	if x != nil {
+		return x.FullLength
+	}
+	return false
+}
+
+func (x *Options) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+// Key is the primary and secondary keys of a value, secondary is blank if there's only one.
+type Key struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Primary       string                 `protobuf:"bytes,1,opt,name=primary,proto3" json:"primary,omitempty"`
+	Secondary     string                 `protobuf:"bytes,2,opt,name=secondary,proto3" json:"secondary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Key) Reset() {
+	*x = Key{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Key) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Key) ProtoMessage() {}
+
+func (x *Key) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Key.ProtoReflect.Descriptor instead.
+func (*Key) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Key) GetPrimary() string {
+	if x != nil {
+		return x.Primary
+	}
+	return ""
+}
+
+func (x *Key) GetSecondary() string {
+	if x != nil {
+		return x.Secondary
+	}
+	return ""
+}
+
+type EncodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Options       *Options               `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncodeRequest) Reset() {
+	*x = EncodeRequest{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeRequest) ProtoMessage() {}
+
+func (x *EncodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeRequest.ProtoReflect.Descriptor instead.
+func (*EncodeRequest) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EncodeRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EncodeRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type EncodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Key           *Key                   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncodeResponse) Reset() {
+	*x = EncodeResponse{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeResponse) ProtoMessage() {}
+
+func (x *EncodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeResponse.ProtoReflect.Descriptor instead.
+func (*EncodeResponse) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EncodeResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EncodeResponse) GetKey() *Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type MatchRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Value      string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Candidates []string               `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Max number of matches to return, 0 for all.
+	Limit         int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Options       *Options `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchRequest) Reset() {
+	*x = MatchRequest{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRequest) ProtoMessage() {}
+
+func (x *MatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRequest.ProtoReflect.Descriptor instead.
+func (*MatchRequest) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MatchRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *MatchRequest) GetCandidates() []string {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *MatchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *MatchRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type Match struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Type  MatchType              `protobuf:"varint,2,opt,name=type,proto3,enum=metaphone3.v1.MatchType" json:"type,omitempty"`
+	// Number of times the value is in the candidates.
+	Count         int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Key           *Key  `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Match) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Match) GetType() MatchType {
+	if x != nil {
+		return x.Type
+	}
+	return MatchType_MATCH_TYPE_UNSPECIFIED
+}
+
+func (x *Match) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Match) GetKey() *Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type MatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *Key                   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Matches       []*Match               `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResponse) Reset() {
+	*x = MatchResponse{}
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResponse) ProtoMessage() {}
+
+func (x *MatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_metaphone3_v1_metaphone3_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResponse.ProtoReflect.Descriptor instead.
+func (*MatchResponse) Descriptor() ([]byte, []int) {
+	return file_metaphone3_v1_metaphone3_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MatchResponse) GetKey() *Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *MatchResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+var File_metaphone3_v1_metaphone3_proto protoreflect.FileDescriptor
+
+const file_metaphone3_v1_metaphone3_proto_rawDesc = "" +
+	"\n" +
+	"\x1emetaphone3/v1/metaphone3.proto\x12\rmetaphone3.v1\"\xa7\x01\n" +
+	"\aOptions\x12#\n" +
+	"\rencode_vowels\x18\x01 \x01(\bR\fencodeVowels\x12!\n" +
+	"\fencode_exact\x18\x02 \x01(\bR\vencodeExact\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x03 \x01(\x05R\tmaxLength\x12\x1f\n" +
+	"\vfull_length\x18\x04 \x01(\bR\n" +
+	"fullLength\x12\x14\n" +
+	"\x05input\x18\x05 \x01(\tR\x05input\"=\n" +
+	"\x03Key\x12\x18\n" +
+	"\aprimary\x18\x01 \x01(\tR\aprimary\x12\x1c\n" +
+	"\tsecondary\x18\x02 \x01(\tR\tsecondary\"W\n" +
+	"\rEncodeRequest\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x120\n" +
+	"\aoptions\x18\x02 \x01(\v2\x16.metaphone3.v1.OptionsR\aoptions\"L\n" +
+	"\x0eEncodeResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12$\n" +
+	"\x03key\x18\x02 \x01(\v2\x12.metaphone3.v1.KeyR\x03key\"\x8c\x01\n" +
+	"\fMatchRequest\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x1e\n" +
+	"\n" +
+	"candidates\x18\x02 \x03(\tR\n" +
+	"candidates\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x120\n" +
+	"\aoptions\x18\x04 \x01(\v2\x16.metaphone3.v1.OptionsR\aoptions\"\x87\x01\n" +
+	"\x05Match\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12,\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.metaphone3.v1.MatchTypeR\x04type\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12$\n" +
+	"\x03key\x18\x04 \x01(\v2\x12.metaphone3.v1.KeyR\x03key\"e\n" +
+	"\rMatchResponse\x12$\n" +
+	"\x03key\x18\x01 \x01(\v2\x12.metaphone3.v1.KeyR\x03key\x12.\n" +
+	"\amatches\x18\x02 \x03(\v2\x14.metaphone3.v1.MatchR\amatches*Y\n" +
+	"\tMatchType\x12\x1a\n" +
+	"\x16MATCH_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12MATCH_TYPE_PRIMARY\x10\x01\x12\x18\n" +
+	"\x14MATCH_TYPE_SECONDARY\x10\x022\xe8\x01\n" +
+	"\n" +
+	"Metaphone3\x12E\n" +
+	"\x06Encode\x12\x1c.metaphone3.v1.EncodeRequest\x1a\x1d.metaphone3.v1.EncodeResponse\x12O\n" +
+	"\fEncodeStream\x12\x1c.metaphone3.v1.EncodeRequest\x1a\x1d.metaphone3.v1.EncodeResponse(\x010\x01\x12B\n" +
+	"\x05Match\x12\x1b.metaphone3.v1.MatchRequest\x1a\x1c.metaphone3.v1.MatchResponseB,Z*github.com/dlclark/metaphone3/metaphone3pbb\x06proto3"
+
+var (
+	file_metaphone3_v1_metaphone3_proto_rawDescOnce sync.Once
+	file_metaphone3_v1_metaphone3_proto_rawDescData []byte
+)
+
+func file_metaphone3_v1_metaphone3_proto_rawDescGZIP() []byte {
+	file_metaphone3_v1_metaphone3_proto_rawDescOnce.Do(func() {
+		file_metaphone3_v1_metaphone3_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_metaphone3_v1_metaphone3_proto_rawDesc), len(file_metaphone3_v1_metaphone3_proto_rawDesc)))
+	})
+	return file_metaphone3_v1_metaphone3_proto_rawDescData
+}
+
+var file_metaphone3_v1_metaphone3_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_metaphone3_v1_metaphone3_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_metaphone3_v1_metaphone3_proto_goTypes = []any{
+	(MatchType)(0),         // 0: metaphone3.v1.MatchType
+	(*Options)(nil),        // 1: metaphone3.v1.Options
+	(*Key)(nil),            // 2: metaphone3.v1.Key
+	(*EncodeRequest)(nil),  // 3: metaphone3.v1.EncodeRequest
+	(*EncodeResponse)(nil), // 4: metaphone3.v1.EncodeResponse
+	(*MatchRequest)(nil),   // 5: metaphone3.v1.MatchRequest
+	(*Match)(nil),          // 6: metaphone3.v1.Match
+	(*MatchResponse)(nil),  // 7: metaphone3.v1.MatchResponse
+}
+var file_metaphone3_v1_metaphone3_proto_depIdxs = []int32{
+	1,  // 0: metaphone3.v1.EncodeRequest.options:type_name -> metaphone3.v1.Options
+	2,  // 1: metaphone3.v1.EncodeResponse.key:type_name -> metaphone3.v1.Key
+	1,  // 2: metaphone3.v1.MatchRequest.options:type_name -> metaphone3.v1.Options
+	0,  // 3: metaphone3.v1.Match.type:type_name -> metaphone3.v1.MatchType
+	2,  // 4: metaphone3.v1.Match.key:type_name -> metaphone3.v1.Key
+	2,  // 5: metaphone3.v1.MatchResponse.key:type_name -> metaphone3.v1.Key
+	6,  // 6: metaphone3.v1.MatchResponse.matches:type_name -> metaphone3.v1.Match
+	3,  // 7: metaphone3.v1.Metaphone3.Encode:input_type -> metaphone3.v1.EncodeRequest
+	3,  // 8: metaphone3.v1.Metaphone3.EncodeStream:input_type -> metaphone3.v1.EncodeRequest
+	5,  // 9: metaphone3.v1.Metaphone3.Match:input_type -> metaphone3.v1.MatchRequest
+	4,  // 10: metaphone3.v1.Metaphone3.Encode:output_type -> metaphone3.v1.EncodeResponse
+	4,  // 11: metaphone3.v1.Metaphone3.EncodeStream:output_type -> metaphone3.v1.EncodeResponse
+	7,  // 12: metaphone3.v1.Metaphone3.Match:output_type -> metaphone3.v1.MatchResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_metaphone3_v1_metaphone3_proto_init() }
+func file_metaphone3_v1_metaphone3_proto_init() {
+	if File_metaphone3_v1_metaphone3_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_metaphone3_v1_metaphone3_proto_rawDesc), len(file_metaphone3_v1_metaphone3_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_metaphone3_v1_metaphone3_proto_goTypes,
+		DependencyIndexes: file_metaphone3_v1_metaphone3_proto_depIdxs,
+		EnumInfos:         file_metaphone3_v1_metaphone3_proto_enumTypes,
+		MessageInfos:      file_metaphone3_v1_metaphone3_proto_msgTypes,
+	}.Build()
+	File_metaphone3_v1_metaphone3_proto = out.File
+	file_metaphone3_v1_metaphone3_proto_goTypes = nil
+	file_metaphone3_v1_metaphone3_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: metaphone3/v1/metaphone3.proto
+
+package metaphone3pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Metaphone3_Encode_FullMethodName       = "/metaphone3.v1.Metaphone3/Encode"
+	Metaphone3_EncodeStream_FullMethodName = "/metaphone3.v1.Metaphone3/EncodeStream"
+	Metaphone3_Match_FullMethodName        = "/metaphone3.v1.Metaphone3/Match"
+)
+
+// Metaphone3Client is the client API for Metaphone3 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Metaphone3 encodes values with the Metaphone 3 algorithm and matches values that sound alike.
+type Metaphone3Client interface {
+	// Encode returns the keys for one value.
+	Encode(ctx context.Context, in *EncodeRequest, opts ...grpc.CallOption) (*EncodeResponse, error)
+	// EncodeStream encodes a stream of values, the responses are sent in the order of the requests.
+	EncodeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EncodeRequest, EncodeResponse], error)
+	// Match returns the candidates that sound like the value, primary matches first and then
+	// the most frequent candidates.
+	Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+}
+
+type metaphone3Client struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMetaphone3Client(cc grpc.ClientConnInterface) Metaphone3Client {
+	return &metaphone3Client{cc}
+}
+
+func (c *metaphone3Client) Encode(ctx context.Context, in *EncodeRequest, opts ...grpc.CallOption) (*EncodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EncodeResponse)
+	err := c.cc.Invoke(ctx, Metaphone3_Encode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metaphone3Client) EncodeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EncodeRequest, EncodeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Metaphone3_ServiceDesc.Streams[0], Metaphone3_EncodeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EncodeRequest, EncodeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Metaphone3_EncodeStreamClient = grpc.BidiStreamingClient[EncodeRequest, EncodeResponse]
+
+func (c *metaphone3Client) Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResponse)
+	err := c.cc.Invoke(ctx, Metaphone3_Match_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Metaphone3Server is the server API for Metaphone3 service.
+// All implementations must embed UnimplementedMetaphone3Server
+// for forward compatibility.
+//
+// Metaphone3 encodes values with the Metaphone 3 algorithm and matches values that sound alike.
+type Metaphone3Server interface {
+	// Encode returns the keys for one value.
+	Encode(context.Context, *EncodeRequest) (*EncodeResponse, error)
+	// EncodeStream encodes a stream of values, the responses are sent in the order of the requests.
+	EncodeStream(grpc.BidiStreamingServer[EncodeRequest, EncodeResponse]) error
+	// Match returns the candidates that sound like the value, primary matches first and then
+	// the most frequent candidates.
+	Match(context.Context, *MatchRequest) (*MatchResponse, error)
+	mustEmbedUnimplementedMetaphone3Server()
+}
+
+// UnimplementedMetaphone3Server must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMetaphone3Server struct{}
+
+func (UnimplementedMetaphone3Server) Encode(context.Context, *EncodeRequest) (*EncodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Encode not implemented")
+}
+func (UnimplementedMetaphone3Server) EncodeStream(grpc.BidiStreamingServer[EncodeRequest, EncodeResponse]) error {
+	return status.Error(codes.Unimplemented, "method EncodeStream not implemented")
+}
+func (UnimplementedMetaphone3Server) Match(context.Context, *MatchRequest) (*MatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Match not implemented")
+}
+func (UnimplementedMetaphone3Server) mustEmbedUnimplementedMetaphone3Server() {}
+func (UnimplementedMetaphone3Server) testEmbeddedByValue()                    {}
+
+// UnsafeMetaphone3Server may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to Metaphone3Server will
+// result in compilation errors.
+type UnsafeMetaphone3Server interface {
+	mustEmbedUnimplementedMetaphone3Server()
+}
+
+func RegisterMetaphone3Server(s grpc.ServiceRegistrar, srv Metaphone3Server) {
+	// If the following call panics, it indicates UnimplementedMetaphone3Server was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Metaphone3_ServiceDesc, srv)
+}
+
+func _Metaphone3_Encode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Metaphone3Server).Encode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metaphone3_Encode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Metaphone3Server).Encode(ctx, req.(*EncodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metaphone3_EncodeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(Metaphone3Server).EncodeStream(&grpc.GenericServerStream[EncodeRequest, EncodeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Metaphone3_EncodeStreamServer = grpc.BidiStreamingServer[EncodeRequest, EncodeResponse]
+
+func _Metaphone3_Match_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Metaphone3Server).Match(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metaphone3_Match_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Metaphone3Server).Match(ctx, req.(*MatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Metaphone3_ServiceDesc is the grpc.ServiceDesc for Metaphone3 service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Metaphone3_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "metaphone3.v1.Metaphone3",
+	HandlerType: (*Metaphone3Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Encode",
+			Handler:    _Metaphone3_Encode_Handler,
+		},
+		{
+			MethodName: "Match",
+			Handler:    _Metaphone3_Match_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EncodeStream",
+			Handler:       _Metaphone3_EncodeStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "metaphone3/v1/metaphone3.proto",
+}
@@ -0,0 +1,169 @@
+package metaphone3
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pattern functions whose lists are scanned in order and must be sorted shortest to longest
+var orderedPatternFuncs = map[string]bool{
+	"stringAt":      true,
+	"stringAtEnd":   true,
+	"stringAtStart": true,
+	"stringStart":   true,
+	"stringExact":   true,
+	"stringEnd":     true,
+	"at":            true,
+	"atEnd":         true,
+	"atStart":       true,
+	"exactly":       true,
+	"endsWith":      true,
+}
+
+// pattern functions that compile their lists, so order doesn't matter
+var unorderedPatternFuncs = map[string]bool{
+	"newWordList": true,
+	"contains":    true,
+}
+
+// TestPatternLists walks every call to the pattern matching functions in the package and checks
+// that the lists are all caps, have no duplicates and (where it matters) are ordered by length.
+// A list that's out of order silently fails to match some of its entries.
+func TestPatternLists(t *testing.T) {
+	fset, files, err := parseSource(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			var name string
+			switch fn := call.Fun.(type) {
+			case *ast.Ident:
+				name = fn.Name
+			case *ast.SelectorExpr:
+				name = fn.Sel.Name
+			}
+			ordered := orderedPatternFuncs[name]
+			if !ordered && !unorderedPatternFuncs[name] {
+				return true
+			}
+
+			var vals []string
+			for _, arg := range call.Args {
+				lit, ok := arg.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				v, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				vals = append(vals, v)
+			}
+			if len(vals) == 0 {
+				return true
+			}
+
+			calls++
+			checkPatternList(t, fset.Position(call.Pos()).String(), name, vals, ordered)
+			return true
+		})
+	}
+
+	if calls == 0 {
+		t.Fatal("no pattern lists found")
+	}
+}
+
+// TestPatternDataFiles checks the word lists in the data files the same way.
+func TestPatternDataFiles(t *testing.T) {
+	files, err := filepath.Glob("data/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no data files found")
+	}
+
+	for _, file := range files {
+		b, err := dataFiles.ReadFile(filepath.ToSlash(file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vals []string
+		scanner := bufio.NewScanner(strings.NewReader(string(b)))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || line[0] == '#' {
+				continue
+			}
+			vals = append(vals, line)
+		}
+		checkPatternList(t, file, "data file", vals, false)
+	}
+}
+
+// parseSource parses the package's non-test Go files in dir
+func parseSource(dir string) (*token.FileSet, []*ast.File, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, f)
+	}
+	return fset, files, nil
+}
+
+func checkPatternList(t *testing.T, pos, name string, vals []string, ordered bool) {
+	t.Helper()
+
+	seen := make(map[string]bool, len(vals))
+	for i, v := range vals {
+		if v == "" {
+			t.Errorf("%v: %v has a blank entry", pos, name)
+			continue
+		}
+		for _, c := range v {
+			if c > 127 {
+				t.Errorf("%v: %v entry %q isn't ascii", pos, name, v)
+				break
+			}
+		}
+		if v != strings.ToUpper(v) {
+			t.Errorf("%v: %v entry %q isn't all caps", pos, name, v)
+		}
+		if seen[v] {
+			t.Errorf("%v: %v entry %q is duplicated", pos, name, v)
+		}
+		seen[v] = true
+
+		if ordered && i > 0 && len(v) < len(vals[i-1]) {
+			t.Errorf("%v: %v entry %q is shorter than the entry before it, %q", pos, name, v, vals[i-1])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package metaphone3
+
+import "sync"
+
+// Pool is a pool of Encoders that share the same options.  Unlike an Encoder it's
+// safe to use across goroutines.
+type Pool struct {
+	opts Encoder
+	pool sync.Pool
+}
+
+// NewPool returns a pool of Encoders with the options of opts.  Only the options are
+// copied, opts itself isn't used by the pool.  If opts is nil the default options are used.
+// The Exceptions, if any, are shared by all the Encoders and must not be changed, and
+// so are the Stats.
+func NewPool(opts *Encoder) *Pool {
+	p := &Pool{}
+	if opts != nil {
+		p.opts = Encoder{
+			EncodeVowels:  opts.EncodeVowels,
+			EncodeExact:   opts.EncodeExact,
+			MaxLength:     opts.MaxLength,
+			FullLength:    opts.FullLength,
+			MaxAlternates: opts.MaxAlternates,
+			Exceptions:    opts.Exceptions,
+			InputPolicy:   opts.InputPolicy,
+			Stats:         opts.Stats,
+		}
+	}
+	p.pool.New = func() any {
+		e := p.opts
+		return &e
+	}
+	return p
+}
+
+// Get returns an Encoder from the pool, it should be given back with Put when done.
+func (p *Pool) Get() *Encoder {
+	return p.pool.Get().(*Encoder)
+}
+
+// Put returns an Encoder from Get to the pool.
+func (p *Pool) Put(e *Encoder) {
+	p.pool.Put(e)
+}
+
+// Encode is Encoder.Encode using an Encoder from the pool.
+func (p *Pool) Encode(in string) (primary, secondary string) {
+	e := p.Get()
+	defer p.Put(e)
+	return e.Encode(in)
+}
+
+// TryEncode is Encoder.TryEncode using an Encoder from the pool.
+func (p *Pool) TryEncode(in string) (primary, secondary string, err error) {
+	e := p.Get()
+	defer p.Put(e)
+	return e.TryEncode(in)
+}
+
+// EncodeKey is Encoder.EncodeKey using an Encoder from the pool.
+func (p *Pool) EncodeKey(in string) Key {
+	e := p.Get()
+	defer p.Put(e)
+	return e.EncodeKey(in)
+}
+
+// EncodeAll is Encoder.EncodeAll using an Encoder from the pool.
+func (p *Pool) EncodeAll(in string) []string {
+	e := p.Get()
+	defer p.Put(e)
+	return e.EncodeAll(in)
+}
@@ -0,0 +1,41 @@
+package metaphone3
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	opts := &Encoder{EncodeVowels: true, MaxLength: 4}
+	p := NewPool(opts)
+	// changing the options afterwards doesn't change the pool
+	opts.MaxLength = 8
+
+	o := KeyOptions{EncodeVowels: true, MaxLength: 4}
+	want := map[string]Key{
+		"Smith":       {"SMA0", "XMAT", o},
+		"Villafranca": {"FALA", "FAFR", o},
+		"Schmidt":     {"XMAT", "", o},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				for in, k := range want {
+					if got := p.EncodeKey(in); got != k {
+						t.Errorf("%v: wanted %v, got %v", in, k, got)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if prim, sec, err := p.TryEncode("Smith"); err != nil || prim != "SMA0" || sec != "XMAT" {
+		t.Fatalf("wanted SMA0/XMAT, got %v/%v %v", prim, sec, err)
+	}
+}
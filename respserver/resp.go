@@ -0,0 +1,152 @@
+package respserver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// errProtocol is a request that isn't valid RESP, the connection is closed after replying
+type errProtocol struct {
+	msg string
+}
+
+func (e *errProtocol) Error() string {
+	return "Protocol error: " + e.msg
+}
+
+func protocolError(format string, args ...any) error {
+	return &errProtocol{fmt.Sprintf(format, args...)}
+}
+
+// reader reads commands, either as RESP arrays of bulk strings or as inline commands
+// like "PING" typed in telnet
+type reader struct {
+	r       *bufio.Reader
+	maxArgs int
+	maxBulk int
+}
+
+// readCommand returns the arguments of the next command, which can be empty for a blank line
+func (r *reader) readCommand() ([]string, error) {
+	line, err := r.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		args := bytes.Fields(line)
+		if len(args) > r.maxArgs {
+			return nil, protocolError("too many arguments")
+		}
+		out := make([]string, len(args))
+		for i, a := range args {
+			out[i] = string(a)
+		}
+		return out, nil
+	}
+
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n > r.maxArgs {
+		return nil, protocolError("invalid multibulk length")
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	args := make([]string, n)
+	for i := range args {
+		if args[i], err = r.readBulk(); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+func (r *reader) readBulk() (string, error) {
+	line, err := r.readLine()
+	if err != nil {
+		return "", err
+	}
+	if len(line) == 0 || line[0] != '$' {
+		return "", protocolError("expected '$', got '%c'", firstByte(line))
+	}
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n < 0 || n > r.maxBulk {
+		return "", protocolError("invalid bulk length")
+	}
+
+	buf := make([]byte, n+2)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return "", err
+	}
+	if buf[n] != '\r' || buf[n+1] != '\n' {
+		return "", protocolError("bulk string isn't followed by CRLF")
+	}
+	return string(buf[:n]), nil
+}
+
+// readLine reads a line without the trailing CRLF (or LF, for inline commands)
+func (r *reader) readLine() ([]byte, error) {
+	line, err := r.r.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return nil, protocolError("line is too long")
+	}
+	if err != nil {
+		return nil, err
+	}
+	line = line[:len(line)-1]
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return line, nil
+}
+
+func firstByte(b []byte) byte {
+	if len(b) == 0 {
+		return ' '
+	}
+	return b[0]
+}
+
+// writer writes RESP2 replies, errors are sticky and returned by flush
+type writer struct {
+	w *bufio.Writer
+}
+
+func (w *writer) simple(s string) {
+	w.w.WriteString("+" + oneLine(s) + "\r\n")
+}
+
+func (w *writer) error(s string) {
+	w.w.WriteString("-" + oneLine(s) + "\r\n")
+}
+
+// oneLine replaces the control characters of a simple string or error, which can echo
+// client input, so a CR or LF can't end the reply early and inject another
+func oneLine(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func (w *writer) int(n int) {
+	w.w.WriteString(":" + strconv.Itoa(n) + "\r\n")
+}
+
+func (w *writer) bulk(s string) {
+	w.w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+func (w *writer) array(n int) {
+	w.w.WriteString("*" + strconv.Itoa(n) + "\r\n")
+}
+
+func (w *writer) flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,285 @@
+// Package respserver serves phonetic lookups over RESP, the Redis protocol, so apps
+// that already talk to Redis can use any Redis client library.  Each key is a separate
+// in-memory phonetic index from the index package.
+//
+// Commands:
+//
+//	PHONADD key name [id]           adds a name to the index at key, replies 1 if the name is new
+//	PHONSEARCH key name [LIMIT n]   replies with the names that sound like name, each as
+//	                                [name, "primary"|"secondary", count, [ids...]]
+//	PHONENCODE name                 replies with [primary, secondary]
+//	PING [message]
+//	QUIT
+//
+// Both RESP arrays and inline commands are accepted, replies are RESP2.
+package respserver
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dlclark/metaphone3"
+	"github.com/dlclark/metaphone3/index"
+)
+
+// ErrServerClosed is returned by Serve after Close.
+var ErrServerClosed = errors.New("respserver: server closed")
+
+var (
+	errTooManyKeys = errors.New("too many keys")
+	errIndexFull   = errors.New("index is full")
+)
+
+// Server is a RESP server.  The indexes are kept in memory and are lost when the
+// process exits.
+type Server struct {
+	// MaxArgs limits the number of arguments of a command
+	MaxArgs int
+	// MaxBulkLen limits the size of each argument in bytes
+	MaxBulkLen int
+	// MaxKeys limits the number of indexes, MaxNames the distinct names in each index and
+	// MaxIDs the ids kept for each name, 0 is no limit.  They bound the memory clients can use.
+	MaxKeys, MaxNames, MaxIDs int
+
+	pool *metaphone3.Pool
+
+	mu        sync.Mutex
+	indexes   map[string]*index.Index
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// New returns a Server that encodes with the options of opts, which are copied.  If
+// opts is nil the default options are used.
+func New(opts *metaphone3.Encoder) *Server {
+	return &Server{
+		MaxArgs:    1024,
+		MaxBulkLen: 64 << 10,
+		MaxKeys:    1024,
+		MaxNames:   1 << 20,
+		MaxIDs:     1024,
+		pool:       metaphone3.NewPool(opts),
+		indexes:    make(map[string]*index.Index),
+		listeners:  make(map[net.Listener]struct{}),
+		conns:      make(map[net.Conn]struct{}),
+	}
+}
+
+// Serve accepts connections on l until Close is called, it always returns a non-nil error.
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		l.Close()
+		return ErrServerClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.mu.Unlock()
+
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			delete(s.listeners, l)
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			c.Close()
+			return ErrServerClosed
+		}
+		s.conns[c] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.serveConn(c)
+	}
+}
+
+// Close stops the listeners, closes every connection and waits for them to finish.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Server) serveConn(c net.Conn) {
+	defer func() {
+		c.Close()
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		s.wg.Done()
+	}()
+
+	r := &reader{r: bufio.NewReaderSize(c, 16<<10), maxArgs: s.MaxArgs, maxBulk: s.MaxBulkLen}
+	w := &writer{w: bufio.NewWriter(c)}
+	for {
+		args, err := r.readCommand()
+		if err != nil {
+			var pe *errProtocol
+			if errors.As(err, &pe) {
+				w.error("ERR " + pe.Error())
+				w.flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		quit := s.exec(w, args)
+		// flush once the pipelined commands have all been read
+		if quit || r.r.Buffered() == 0 {
+			if err := w.flush(); err != nil || quit {
+				return
+			}
+		}
+	}
+}
+
+// exec runs a command and writes the reply, it returns true if the connection should be closed
+func (s *Server) exec(w *writer, args []string) bool {
+	name := strings.ToLower(args[0])
+	switch name {
+	case "ping":
+		switch len(args) {
+		case 1:
+			w.simple("PONG")
+		case 2:
+			w.bulk(args[1])
+		default:
+			wrongArgs(w, name)
+		}
+	case "quit":
+		w.simple("OK")
+		return true
+	case "command":
+		// redis-cli asks for the command docs when it connects
+		w.array(0)
+	case "phonadd":
+		if len(args) != 3 && len(args) != 4 {
+			wrongArgs(w, name)
+			break
+		}
+		id := ""
+		if len(args) == 4 {
+			id = args[3]
+		}
+		n, err := s.add(args[1], args[2], id)
+		if err != nil {
+			w.error("ERR " + err.Error())
+			break
+		}
+		w.int(n)
+	case "phonsearch":
+		limit := 0
+		switch {
+		case len(args) == 5 && strings.EqualFold(args[3], "limit"):
+			n, err := strconv.Atoi(args[4])
+			if err != nil || n < 0 {
+				w.error("ERR value is not an integer or out of range")
+				return false
+			}
+			limit = n
+		case len(args) == 5:
+			w.error("ERR syntax error")
+			return false
+		case len(args) != 3:
+			wrongArgs(w, name)
+			return false
+		}
+		s.search(w, args[1], args[2], limit)
+	case "phonencode":
+		if len(args) != 2 {
+			wrongArgs(w, name)
+			break
+		}
+		prim, sec := s.pool.Encode(args[1])
+		w.array(2)
+		w.bulk(prim)
+		w.bulk(sec)
+	default:
+		w.error("ERR unknown command '" + args[0] + "'")
+	}
+	return false
+}
+
+func wrongArgs(w *writer, name string) {
+	w.error("ERR wrong number of arguments for '" + name + "' command")
+}
+
+// add adds a name to the index at key, creating the index if needed, and returns 1 if
+// the name is new.  It returns an error if a limit would be passed.
+func (s *Server) add(key, name, id string) (int, error) {
+	s.mu.Lock()
+	ix, ok := s.indexes[key]
+	if !ok {
+		if s.MaxKeys > 0 && len(s.indexes) >= s.MaxKeys {
+			s.mu.Unlock()
+			return 0, errTooManyKeys
+		}
+		// an Encoder from the pool is never put back, the index owns it
+		ix = index.New(s.pool.Get())
+		s.indexes[key] = ix
+	}
+	s.mu.Unlock()
+
+	ent, ok, err := ix.AddLimited(name, id, index.Limits{Values: s.MaxNames, IDs: s.MaxIDs})
+	if err != nil {
+		return 0, errIndexFull
+	}
+	if ok && ent.Count == 1 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// search writes the matches for a name in the index at key, a missing key has no matches
+func (s *Server) search(w *writer, key, name string, limit int) {
+	s.mu.Lock()
+	ix := s.indexes[key]
+	s.mu.Unlock()
+	if ix == nil {
+		w.array(0)
+		return
+	}
+
+	ms := ix.Search(name)
+	if limit > 0 && len(ms) > limit {
+		ms = ms[:limit]
+	}
+	w.array(len(ms))
+	for _, m := range ms {
+		w.array(4)
+		w.bulk(m.Entry.Value)
+		w.bulk(m.Type.String())
+		w.int(m.Entry.Count)
+		w.array(len(m.Entry.IDs))
+		for _, id := range m.Entry.IDs {
+			w.bulk(id)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package respserver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dlclark/metaphone3"
+)
+
+func startServer(t *testing.T) string {
+	t.Helper()
+	return serve(t, New(nil))
+}
+
+// serve starts s on a local port and returns the address
+func serve(t *testing.T, s *Server) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(l) }()
+	t.Cleanup(func() {
+		s.Close()
+		if err := <-done; !errors.Is(err, ErrServerClosed) {
+			t.Errorf("Serve returned %v", err)
+		}
+	})
+	return l.Addr().String()
+}
+
+// client is a minimal RESP client, replies are decoded as string, int64, error or []any
+type client struct {
+	c net.Conn
+	r *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *client {
+	t.Helper()
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return &client{c: c, r: bufio.NewReader(c)}
+}
+
+func (c *client) send(args ...string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	io.WriteString(c.c, b.String())
+}
+
+func (c *client) do(t *testing.T, args ...string) any {
+	t.Helper()
+	c.send(args...)
+	return c.reply(t)
+}
+
+func (c *client) reply(t *testing.T) any {
+	t.Helper()
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	switch line[0] {
+	case '+':
+		return line[1:]
+	case '-':
+		return errors.New(line[1:])
+	case ':':
+		n, _ := strconv.ParseInt(line[1:], 10, 64)
+		return n
+	case '$':
+		n, _ := strconv.Atoi(line[1:])
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			t.Fatal(err)
+		}
+		return string(buf[:n])
+	case '*':
+		n, _ := strconv.Atoi(line[1:])
+		out := []any{}
+		for i := 0; i < n; i++ {
+			out = append(out, c.reply(t))
+		}
+		return out
+	}
+	t.Fatalf("unexpected reply %q", line)
+	return nil
+}
+
+func TestCommands(t *testing.T) {
+	c := dial(t, startServer(t))
+
+	check := func(want any, args ...string) {
+		t.Helper()
+		got := c.do(t, args...)
+		if err, ok := got.(error); ok {
+			got = "-" + err.Error()
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: wanted %#v, got %#v", args, want, got)
+		}
+	}
+
+	check("PONG", "PING")
+	check("hello", "ping", "hello")
+	check([]any{"SM0", "XMT"}, "PHONENCODE", "Smith")
+	check([]any{"XMT", ""}, "phonencode", "Schmidt")
+
+	check(int64(1), "PHONADD", "people", "Smith", "1")
+	check(int64(1), "PHONADD", "people", "Smyth", "2")
+	check(int64(1), "PHONADD", "people", "Schmidt")
+	check(int64(0), "PHONADD", "people", "Smith", "4")
+	check(int64(0), "PHONADD", "people", "", "5")
+	check(int64(1), "PHONADD", "places", "Smithville")
+
+	check([]any{
+		[]any{"Smith", "primary", int64(2), []any{"1", "4"}},
+		[]any{"Smyth", "primary", int64(1), []any{"2"}},
+		[]any{"Schmidt", "secondary", int64(1), []any{}},
+	}, "PHONSEARCH", "people", "Smythe")
+	check([]any{
+		[]any{"Smith", "primary", int64(2), []any{"1", "4"}},
+	}, "PHONSEARCH", "people", "Smythe", "limit", "1")
+	check([]any{}, "PHONSEARCH", "people", "Jones")
+	check([]any{}, "PHONSEARCH", "nobody", "Smith")
+
+	check("-ERR unknown command 'GET'", "GET", "people")
+	// a reply can't be split by input echoed in an error
+	check("-ERR unknown command 'GET  +OK  '", "GET\r\n+OK\r\n")
+	check("PONG", "PING")
+	check("-ERR wrong number of arguments for 'phonadd' command", "PHONADD", "people")
+	check("-ERR wrong number of arguments for 'phonencode' command", "PHONENCODE")
+	check("-ERR syntax error", "PHONSEARCH", "people", "Smith", "COUNT", "1")
+	check("-ERR value is not an integer or out of range", "PHONSEARCH", "people", "Smith", "LIMIT", "x")
+	check([]any{}, "COMMAND", "DOCS")
+	check("OK", "QUIT")
+	if _, err := c.r.ReadByte(); err != io.EOF {
+		t.Errorf("wanted the connection closed after QUIT, got %v", err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	s := New(nil)
+	s.MaxKeys, s.MaxNames, s.MaxIDs = 2, 2, 1
+	c := dial(t, serve(t, s))
+
+	for _, v := range []struct {
+		want any
+		args []string
+	}{
+		{int64(1), []string{"PHONADD", "people", "Smith", "1"}},
+		{int64(1), []string{"PHONADD", "people", "Jones"}},
+		{"-ERR index is full", []string{"PHONADD", "people", "Brown"}},
+		{"-ERR index is full", []string{"PHONADD", "people", "Smith", "2"}},
+		// names and ids that are already there can be added again
+		{int64(0), []string{"PHONADD", "people", "Smith", "1"}},
+		{int64(1), []string{"PHONADD", "places", "Smithville"}},
+		{"-ERR too many keys", []string{"PHONADD", "things", "Smith"}},
+		{[]any{[]any{"Smith", "primary", int64(2), []any{"1"}}}, []string{"PHONSEARCH", "people", "Smith"}},
+	} {
+		got := c.do(t, v.args...)
+		if err, ok := got.(error); ok {
+			got = "-" + err.Error()
+		}
+		if !reflect.DeepEqual(got, v.want) {
+			t.Errorf("%v: wanted %#v, got %#v", v.args, v.want, got)
+		}
+	}
+}
+
+func TestInlineAndPipelined(t *testing.T) {
+	c := dial(t, startServer(t))
+
+	io.WriteString(c.c, "PING\r\n\r\nPHONENCODE  Smith\nPHONADD people Smith 1\r\n")
+	for _, want := range []any{"PONG", []any{"SM0", "XMT"}, int64(1)} {
+		if got := c.reply(t); !reflect.DeepEqual(got, want) {
+			t.Errorf("wanted %#v, got %#v", want, got)
+		}
+	}
+
+	c.send("PHONADD", "people", "Smyth")
+	c.send("PHONSEARCH", "people", "Smith")
+	c.send("PING")
+	if got := c.reply(t); got != int64(1) {
+		t.Errorf("PHONADD: got %#v", got)
+	}
+	if got := c.reply(t).([]any); len(got) != 2 {
+		t.Errorf("PHONSEARCH: wanted 2 matches, got %#v", got)
+	}
+	if got := c.reply(t); got != "PONG" {
+		t.Errorf("PING: got %#v", got)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	addr := startServer(t)
+	for _, in := range []string{
+		"*1\r\n:5\r\n",
+		"*x\r\n",
+		"*1\r\n$-1\r\n",
+		"*1\r\n$100000000\r\n",
+		"*1\r\n$4\r\nPINGxx",
+		"*100000\r\n",
+	} {
+		c := dial(t, addr)
+		io.WriteString(c.c, in)
+		got, ok := c.reply(t).(error)
+		if !ok || !strings.HasPrefix(got.Error(), "ERR Protocol error") {
+			t.Errorf("%q: wanted a protocol error, got %#v", in, got)
+		}
+		if _, err := c.r.ReadByte(); err != io.EOF {
+			t.Errorf("%q: wanted the connection closed, got %v", in, err)
+		}
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	addr := startServer(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := dial(t, addr)
+			for j := 0; j < 50; j++ {
+				c.send("PHONADD", "people", "Smith", strconv.Itoa(i*100+j))
+				c.send("PHONSEARCH", "people", "Smyth")
+			}
+			for j := 0; j < 100; j++ {
+				if err, ok := c.reply(t).(error); ok {
+					t.Error(err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got := dial(t, addr).do(t, "PHONSEARCH", "people", "Smith").([]any)
+	if len(got) != 1 || got[0].([]any)[2] != int64(400) || len(got[0].([]any)[3].([]any)) != 400 {
+		t.Errorf("wanted Smith with a count of 400, got %v", got)
+	}
+}
+
+func TestStats(t *testing.T) {
+	stats := metaphone3.NewStats()
+	s := New(&metaphone3.Encoder{Stats: stats})
+	s.add("people", "Smith", "1")
+	s.add("people", "Smyth", "2")
+	if snap := stats.Snapshot(); snap.Inputs != 2 || snap.Rules["encodeTh"] != 2 {
+		t.Fatalf("unexpected counts %+v", snap)
+	}
+}
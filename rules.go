@@ -0,0 +1,314 @@
+package metaphone3
+
+import "fmt"
+
+// A rule is an encoding rule for the letter at the current index.  Rules for a letter
+// are kept in a list and tried in order; the first one whose context matches fires and
+// the rest are skipped, unless it's marked to continue.
+//
+// Every letter is encoded by a list of rules.  Where the rules are pattern tests they're
+// declarative (see rules_consonants.go), the rest are hand-written helpers in a rule's do
+// since they loop over the input, carry state between letters or look at what's been
+// encoded so far.  The types are unexported while the set of conds settles.
+//
+// When a rule fires it adds its output, advances the index past the letters it consumed
+// and then tries its sub rules (if any) from the new index in the same way.
+type rule struct {
+	// name identifies the rule, it's usually the name of the hand-written helper it replaced
+	name string
+
+	// when is the context that must match for the rule to fire, the zero value always matches
+	when cond
+
+	// do is a hand-written rule that's used instead of when, it fires if it returns true and
+	// does its own adding and advancing
+	do func(*Encoder) bool
+
+	// add is added to the outputs, exact is added instead when EncodeExact is set and
+	// vowels is added instead when EncodeVowels is set.  A rule can't have both exact and
+	// vowels, see checkRules.  If nil nothing is added.
+	add, exact, vowels *output
+
+	// next is how far to advance the index past the current letter
+	next advance
+
+	// cont continues on to the next rule in the list after this one fires
+	cont bool
+
+	// then are sub rules that are tried after this rule fires
+	then []rule
+}
+
+// output is what a rule adds to the primary and secondary outputs.  A blank
+// secondary adds nothing to the secondary.
+type output struct {
+	prim, second string
+	// weight is the likelihood of the secondary for EncodeWeighted, 0 for altEven
+	weight float64
+}
+
+// same adds the same value to both outputs.
+func same(val string) *output {
+	return &output{prim: val, second: val}
+}
+
+// alt adds different values to the primary and secondary outputs.
+func alt(prim, second string) *output {
+	return &output{prim: prim, second: second}
+}
+
+// altWeight is alt with the likelihood of the secondary, e.g. altUnlikely.
+func altWeight(prim, second string, weight float64) *output {
+	return &output{prim: prim, second: second, weight: weight}
+}
+
+// advance is how far past the current letter a rule moves the index, vowels is
+// used instead when EncodeVowels is set
+type advance struct {
+	n, vowels int
+}
+
+// skip advances by n letters regardless of the options.
+func skip(n int) advance {
+	return advance{n, n}
+}
+
+// advanceBy advances by n letters, or by vowels letters when EncodeVowels is set, see advanceCounter.
+func advanceBy(n, vowels int) advance {
+	return advance{n, vowels}
+}
+
+type condKind int
+
+const (
+	condAlways condKind = iota
+	condAt
+	condAtEnd
+	condStart
+	condExact
+	condEnd
+	condContains
+	condList
+	condIdx
+	condIdxAbove
+	condLast
+	condHas
+	condVowel
+	condAll
+	condAny
+	condNot
+
+	// numCondKinds is the number of kinds, TestCondKinds checks that each one is handled
+	numCondKinds
+)
+
+// cond is a test of the context around the current letter
+type cond struct {
+	kind   condKind
+	offset int
+	vals   []string
+	list   *wordList
+	sub    []cond
+}
+
+// lists with at least this many vals are compiled into a wordList
+const compileMinVals = 6
+
+// at matches one of the vals at the relative offset, see stringAt.
+func at(offset int, vals ...string) cond {
+	if len(vals) >= compileMinVals {
+		return atList(offset, newWordList(vals...))
+	}
+	return cond{kind: condAt, offset: offset, vals: vals}
+}
+
+// atEnd matches one of the vals at the relative offset that finishes the input, see stringAtEnd.
+func atEnd(offset int, vals ...string) cond {
+	return cond{kind: condAtEnd, offset: offset, vals: vals}
+}
+
+// atStart matches if the input starts with one of the vals, see stringStart.
+func atStart(vals ...string) cond {
+	return cond{kind: condStart, vals: vals}
+}
+
+// exactly matches if the input is one of the vals, see stringExact.
+func exactly(vals ...string) cond {
+	return cond{kind: condExact, vals: vals}
+}
+
+// endsWith matches if the input ends with one of the vals, see stringEnd.
+func endsWith(vals ...string) cond {
+	return cond{kind: condEnd, vals: vals}
+}
+
+// contains matches if the val is anywhere in the input, see stringContains.
+func contains(val string) cond {
+	return cond{kind: condContains, vals: []string{val}}
+}
+
+// atList matches one of the words in the list at the relative offset, see listAt.
+func atList(offset int, l *wordList) cond {
+	return cond{kind: condList, offset: offset, list: l}
+}
+
+// idxIs matches if the current index is n.
+func idxIs(n int) cond {
+	return cond{kind: condIdx, offset: n}
+}
+
+// idxAbove matches if the current index is greater than n.
+func idxAbove(n int) cond {
+	return cond{kind: condIdxAbove, offset: n}
+}
+
+// atLast matches if the relative offset is the last letter of the input.
+func atLast(offset int) cond {
+	return cond{kind: condLast, offset: offset}
+}
+
+// has matches if the relative offset is within the input.
+func has(offset int) cond {
+	return cond{kind: condHas, offset: offset}
+}
+
+// vowelAt matches if there's a vowel at the relative offset, see isVowelAt.
+func vowelAt(offset int) cond {
+	return cond{kind: condVowel, offset: offset}
+}
+
+// allOf matches if all of the conds match.
+func allOf(conds ...cond) cond {
+	return cond{kind: condAll, sub: conds}
+}
+
+// anyOf matches if any of the conds match.
+func anyOf(conds ...cond) cond {
+	return cond{kind: condAny, sub: conds}
+}
+
+// not matches if the cond doesn't match.
+func not(c cond) cond {
+	return cond{kind: condNot, sub: []cond{c}}
+}
+
+// test evaluates the cond at the current index
+func (e *Encoder) test(c cond) bool {
+	switch c.kind {
+	case condAlways:
+		return true
+	case condAt:
+		return e.stringAt(c.offset, c.vals...)
+	case condAtEnd:
+		return e.stringAtEnd(c.offset, c.vals...)
+	case condStart:
+		return e.stringStart(c.vals...)
+	case condExact:
+		return e.stringExact(c.vals...)
+	case condEnd:
+		return e.stringEnd(c.vals...)
+	case condContains:
+		return e.stringContains(c.vals[0])
+	case condList:
+		return e.listAt(c.offset, c.list)
+	case condIdx:
+		return e.idx == c.offset
+	case condIdxAbove:
+		return e.idx > c.offset
+	case condLast:
+		return e.idx+c.offset == e.lastIdx
+	case condHas:
+		at := e.idx + c.offset
+		return at >= 0 && at < len(e.in)
+	case condVowel:
+		return e.isVowelAt(c.offset)
+	case condAll:
+		for _, s := range c.sub {
+			if !e.test(s) {
+				return false
+			}
+		}
+		return true
+	case condAny:
+		for _, s := range c.sub {
+			if e.test(s) {
+				return true
+			}
+		}
+		return false
+	case condNot:
+		return !e.test(c.sub[0])
+	}
+	return false
+}
+
+// applyRules fires the first matching rule in the list and returns true if any rule fired
+func (e *Encoder) applyRules(rules []rule) bool {
+	fired := false
+	for i := range rules {
+		r := &rules[i]
+		if r.do != nil {
+			if !r.do(e) {
+				continue
+			}
+		} else if !e.test(r.when) {
+			continue
+		}
+
+		e.fire(r)
+		fired = true
+		if !r.cont {
+			break
+		}
+	}
+	return fired
+}
+
+func (e *Encoder) fire(r *rule) {
+	if r.name != "" {
+		e.fired(r.name)
+	}
+	out := r.add
+	if e.EncodeExact && r.exact != nil {
+		out = r.exact
+	}
+	if e.EncodeVowels && r.vowels != nil {
+		out = r.vowels
+	}
+	if out != nil {
+		weight := out.weight
+		if weight == 0 {
+			weight = altEven
+		}
+		e.metaphAddStrWeight(out.prim, out.second, weight)
+	}
+
+	if e.EncodeVowels {
+		e.idx += r.next.vowels
+	} else {
+		e.idx += r.next.n
+	}
+
+	if r.then != nil {
+		e.applyRules(r.then)
+	}
+}
+
+// checkRules returns an error for the first rule in rules, or their sub rules, that's
+// malformed: it has both exact and vowels, since there's no output for both options, or
+// it has both a do and a when, which would be ignored
+func checkRules(rules []rule) error {
+	for i := range rules {
+		r := &rules[i]
+		if r.exact != nil && r.vowels != nil {
+			return fmt.Errorf("rule %d %q: has both exact and vowels", i, r.name)
+		}
+		if r.do != nil && r.when.kind != condAlways {
+			return fmt.Errorf("rule %d %q: has both a do and a when", i, r.name)
+		}
+		if err := checkRules(r.then); err != nil {
+			return fmt.Errorf("rule %d %q: %w", i, r.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package metaphone3
+
+// Rules for the consonants that are encoded by declarative rules rather than by hand-written
+// helpers.  The rule names match the helpers they replaced.
+
+var rulesB = []rule{
+	// silent 'B' for cases not covered under "-mb-", 'debt', 'doubt', 'subtle'
+	{name: "encodeSilentB", when: anyOf(at(-2, "DEBT", "SUBTL", "SUBTIL"), at(-3, "DOUBT")),
+		add: same("T"), next: skip(1)},
+
+	// "-mb", e.g", "dumb", already skipped over under
+	// 'M', altho it should really be handled here...
+	// skip double B, or BPx where X isn't H
+	{name: "encodeB", when: anyOf(at(1, "B"), allOf(at(1, "P"), has(2), not(at(2, "H")))),
+		add: same("P"), exact: same("B"), next: skip(1)},
+	{name: "encodeB", add: same("P"), exact: same("B")},
+}
+
+var rulesD = []rule{
+	{name: "encodeDg", when: at(0, "DG"), then: []rule{
+		// excludes exceptions e.g. 'edgar',
+		// or cases where 'g' is first letter of combining form
+		// e.g. 'handgun', 'waldglas', "midgut", "handgrip", "mudgard", "woodgrouse"
+		{when: anyOf(at(2, "A", "O"),
+			at(1, "GUN", "GUT", "GEAR", "GLAS", "GRIP", "GREN", "GILL", "GRAF",
+				"GUARD", "GUILT", "GRAVE", "GRASS", "GROUSE")),
+			add: same("TK"), exact: same("DG"), next: skip(1)},
+		// e.g. "edge", "abridgment"
+		{add: same("J"), next: skip(1)},
+	}},
+
+	// e.g. "adjacent"
+	{name: "encodeDj", when: at(0, "DJ"), add: same("J"), next: skip(1)},
+
+	// eat redundant 'T' or 'D'
+	{name: "encodeDtDd", when: at(0, "DT", "DD"), then: []rule{
+		{when: at(0, "DTH"), add: same("T0"), exact: same("D0"), next: skip(2)},
+		// devoice it when exact
+		{when: at(0, "DT"), add: same("T"), next: skip(1)},
+		{add: same("T"), exact: same("D"), next: skip(1)},
+	}},
+
+	{name: "encodeDToJ", when: anyOf(
+		// e.g. "module", "adulate"
+		allOf(at(0, "DUL"), vowelAt(-1), vowelAt(3)),
+		// e.g. "soldier", "grandeur", "procedure"
+		atEnd(-1, "LDIER", "NDEUR", "EDURE", "RDURE"),
+		at(-3, "CORDIAL"),
+		// e.g. "pendulum", "education"
+		// e.g. "individual", "individual", "residuum"
+		at(-1, "ADUA", "IDUA", "IDUU", "NDULA", "NDULU", "EDUCA")),
+		add: alt("J", "T"), exact: alt("J", "D"), next: advanceBy(1, 0)},
+
+	// e.g. "assiduous", "arduous"
+	{name: "encodeDous", when: at(1, "UOUS"),
+		add: alt("J", "T"), exact: alt("J", "D"), next: advanceBy(3, 0)},
+
+	// silent 'D' e.g. 'wednesday', 'handsome'
+	{name: "encodeSilentD", when: anyOf(at(-2, "WEDNESDAY"),
+		at(-3, "HANDKER", "HANDSOM", "WINDSOR"),
+		// french silent D at end in words or names familiar to americans
+		endsWith("PERNOD", "ARTAUD", "RENAUD", "RIMBAUD", "MICHAUD", "BICHAUD"))},
+
+	// "final de-voicing" in this case
+	// e.g. 'missed' == 'mist'
+	{name: "encodeD", when: atEnd(-3, "SSED"), add: same("T")},
+	{name: "encodeD", add: same("T"), exact: same("D")},
+}
+
+var rulesF = []rule{
+	// Encode cases where "-FT-" => "T" is usually silent
+	// e.g. 'often', 'soften'
+	// This should really be covered under "T"!
+	{name: "encodeF", when: at(-1, "OFTEN"), add: alt("F", "FT"), next: skip(1)},
+
+	// eat redundant 'F'
+	{name: "encodeF", when: at(1, "F"), add: same("F"), next: skip(1)},
+	{name: "encodeF", add: same("F")},
+}
+
+var rulesK = []rule{
+	{name: "encodeSilentK", when: allOf(idxIs(0), atStart("KN"), not(at(2, "ISH", "ESSET", "IEVEL")))},
+
+	// e.g. "know", "knit", "knob"
+	{name: "encodeSilentK", when: anyOf(
+		allOf(at(1, "NOW", "NIT", "NOT", "NOB"), not(atStart("BANKNOTE"))),
+		at(1, "NOCK", "NUCK", "NIFE", "NACK", "NIGHT")), then: []rule{
+		// N already encoded before
+		// e.g. "penknife"
+		{when: allOf(idxAbove(0), at(-1, "N")), next: skip(1)},
+		{},
+	}},
+
+	// eat redundant K's and Q's
+	{name: "encodeK", when: at(1, "K", "Q"), add: same("K"), next: skip(1)},
+	{name: "encodeK", add: same("K")},
+}
+
+var rulesN = []rule{
+	// Encode "-NCE-" and "-NSE-" "entrance" is pronounced exactly the same as
+	// "entrants", 'acceptance', 'accountancy'
+	{name: "encodeNce", when: allOf(at(1, "C", "S"), at(2, "E", "Y", "I"),
+		anyOf(atLast(2), allOf(atLast(3), at(3, "S")))),
+		add: same("NTS"), next: skip(1)},
+
+	// eat redundant 'N', the context is checked after skipping it
+	{name: "encodeN", when: at(1, "N"), next: skip(1), then: []rule{
+		{when: at(-2, "NENESS", "MONSIEUR")},
+		{add: same("N")},
+	}},
+
+	// e.g. "aloneness",
+	{name: "encodeN", when: at(-2, "NENESS", "MONSIEUR")},
+	{name: "encodeN", add: same("N")},
+}
+
+var rulesP = []rule{
+	{name: "encodeSilentPAtBeginning", when: allOf(idxIs(0), at(0, "PN", "PF", "PS", "PT"))},
+
+	// 'pterodactyl', 'receipt', 'asymptote'
+	{name: "encodePt", when: allOf(at(1, "T"),
+		anyOf(allOf(idxIs(0), at(0, "PTERO")), at(-5, "RECEIPT"), at(-4, "ASYMPTOT"))),
+		add: same("T"), next: skip(1)},
+
+	// Encode "-PH-", usually as F, with exceptions for cases where it is silent, or
+	// where the 'P' and 'T' are pronounced seperately because they belong to two
+	// different words in a combining form
+	{name: "encodePh", when: at(1, "H"), then: []rule{
+		// 'PH' silent in these contexts
+		{when: anyOf(at(0, "PHTHALEIN"), allOf(idxIs(0), at(0, "PHTH")), at(-3, "APOPHTHEGM")),
+			add: same("0"), next: skip(3)},
+		// combining forms
+		// 'sheepherd', 'upheaval', 'cupholder'
+		{when: allOf(idxAbove(0), atList(2, encodePhWords), not(at(-1, "LPHAM")), not(at(-3, "LYMPH", "NYMPH"))),
+			add: same("P"), next: advanceBy(2, 1)},
+		{add: same("F"), next: skip(1)},
+	}},
+
+	// 'sappho'
+	{name: "encodePph", when: allOf(at(1, "P"), at(2, "H")), add: same("F"), next: skip(2)},
+
+	// '-corps-', 'corpsman'
+	{name: "encodeRps", when: allOf(at(-3, "CORPS"), not(at(-3, "CORPSE"))), next: skip(1)},
+
+	// 'coup'
+	{name: "encodeCoup", when: allOf(atEnd(-3, "COUP"), not(at(-5, "RECOUP")))},
+
+	// '-pneum-'
+	{name: "encodePneum", when: at(1, "NEUM"), add: same("N"), next: skip(1)},
+
+	// '-psych-'
+	{name: "encodePsych", when: at(1, "SYCH"), add: same("SK"), vowels: same("SAK"), next: skip(4)},
+
+	{name: "encodePsalm", when: at(1, "SALM"), add: same("SM"), vowels: same("SAM"), next: skip(4)},
+
+	// e.g. "campbell", "raspberry"
+	// eat redundant 'P' or 'B'
+	{name: "encodePb", when: at(1, "P", "B"), add: same("P"), next: skip(1)},
+	{name: "encodeP", add: same("P")},
+}
+
+var rulesQ = []rule{
+	// current pinyin
+	{name: "encodeQ", when: at(0, "QIN"), add: same("X")},
+
+	// eat redundant 'Q'
+	{name: "encodeQ", when: at(1, "Q"), add: same("K"), next: skip(1)},
+	{name: "encodeQ", add: same("K")},
+}
+
+var rulesV = []rule{
+	{name: "encodeV", when: at(1, "V"), add: same("F"), exact: same("V"), next: skip(1)},
+	{name: "encodeV", add: same("F"), exact: same("V")},
+}
+
+var rulesX = []rule{
+	// current chinese pinyin spelling
+	{name: "encodeInitialX", when: atStart("XU", "XIA", "XIO", "XIE"), add: same("X")},
+	{name: "encodeInitialX", when: idxIs(0), add: same("S")},
+
+	// 'xylophone', xylem', 'xanthoma', 'xeno-'
+	{name: "encodeGreekX", when: at(1, "YLO", "YLE", "ENO", "ANTH"), add: same("S")},
+
+	// special cases, "LUXUR-", "Texeira"
+	{name: "encodeXSpecialCases", when: at(-2, "LUXUR"), add: same("KJ"), exact: same("GJ")},
+	{name: "encodeXSpecialCases", when: atStart("TEXEIRA", "TEIXEIRA"), add: same("X")},
+
+	// special case where americans know the proper mexican indian
+	// pronounciation of this name
+	{name: "encodeXToH", when: anyOf(at(-2, "OAXACA"), at(-3, "QUIXOTE")), add: same("H")},
+
+	// e.g. "sexual", "connexion" (british), "noxious"
+	{name: "encodeXVowel", when: at(1, "UAL", "ION", "IOU"), add: alt("KX", "KS"), next: advanceBy(2, 0)},
+
+	// the 'X' is silent at the end of french words, otherwise KS and keep going
+	{name: "encodeFrenchXFinal", when: not(allOf(atLast(0),
+		anyOf(at(-3, "IAU", "EAU", "IEU"), at(-2, "AI", "AU", "OU", "OI", "EU")))),
+		add: same("KS"), cont: true},
+
+	// eat redundant 'X' or other redundant cases
+	// e.g. "excite", "exceed"
+	{name: "encodeX", when: at(1, "X", "Z", "S", "CI", "CE"), next: skip(1)},
+}
+
+var rulesZ = []rule{
+	// Encode cases of "-ZZ-" where it is obviously part of an italian word where
+	// "-ZZ-" is pronounced as TS, "abruzzi", 'pizza'
+	{name: "encodeZz", when: allOf(at(1, "Z"),
+		anyOf(atEnd(2, "I", "O", "A"), at(-2, "MOZZARELL", "PIZZICATO", "PUZZONLAN"))),
+		add: alt("TS", "S"), next: skip(1)},
+
+	{name: "encodeZuZierZs", when: anyOf(allOf(idxIs(1), at(-1, "AZUR")),
+		allOf(at(0, "ZIER"), not(at(-2, "VIZIER"))),
+		at(0, "ZSA")), add: alt("J", "S"), then: []rule{
+		{when: at(0, "ZSA"), next: skip(1)},
+		{},
+	}},
+
+	// Encode cases where americans recognize "-EZ" as part of a french word where Z
+	// not pronounced
+	{name: "encodeFrenchEz", when: anyOf(allOf(idxIs(3), at(-3, "CHEZ")), at(-5, "RENDEZ"))},
+
+	// Encode cases where "-Z-" is in a german word where Z => TS in german
+	{name: "encodeGermanZ", when: anyOf(exactly("NAZI"),
+		at(-2, "NAZIFY", "MOZART"),
+		at(-3, "HOLZ", "HERZ", "MERZ", "FITZ", "HERZOG"),
+		allOf(at(-3, "GANZ"), not(vowelAt(1))),
+		at(-4, "STOLZ", "PRINZ", "VENEZIA"),
+		// german words containing with "sch" but not schlimazel, schmooze
+		allOf(contains("SCH"), not(endsWith("IZE", "OZE", "ZEL"))),
+		allOf(idxAbove(0), at(0, "ZEIT")),
+		at(-3, "WEIZ")), then: []rule{
+		{when: allOf(idxAbove(0), at(-1, "T")), add: same("S")},
+		{add: same("TS")},
+	}},
+
+	// chinese pinyin e.g. 'zhao', also english "phonetic spelling"
+	{name: "encodeZh", when: at(1, "H"), add: same("J"), next: skip(1)},
+
+	// eat redundant 'Z'
+	{name: "encodeZ", when: at(1, "Z"), add: same("S"), next: skip(1)},
+	{name: "encodeZ", add: same("S")},
+}
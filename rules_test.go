@@ -0,0 +1,137 @@
+package metaphone3
+
+import "testing"
+
+func TestApplyRules(t *testing.T) {
+	rules := []rule{
+		{name: "first", when: at(0, "AB"), add: alt("X", "Y"), exact: same("Z"), next: skip(1), then: []rule{
+			{when: at(1, "C"), add: same("C")},
+			{add: same("Q")},
+		}},
+		{name: "keepGoing", when: at(0, "B"), add: same("B"), cont: true},
+		{name: "last", when: anyOf(at(1, "D"), not(has(1))), add: same("D"), vowels: same("DA"), next: advanceBy(1, 2)},
+	}
+
+	vals := []struct {
+		in           string
+		idx          int
+		exact, vowel bool
+		prim, sec    string
+		fired        bool
+		nextIdx      int
+	}{
+		{"ABC", 0, false, false, "XC", "YC", true, 1},
+		{"ABX", 0, true, false, "ZQ", "ZQ", true, 1},
+		{"BD", 0, false, false, "BD", "BD", true, 1},
+		{"BD", 0, false, true, "BDA", "BDA", true, 2},
+		{"XB", 1, false, false, "BD", "BD", true, 2},
+		{"BX", 0, false, false, "B", "B", true, 0},
+		{"XX", 0, false, false, "", "", false, 0},
+	}
+
+	for _, v := range vals {
+		e := &Encoder{EncodeExact: v.exact, EncodeVowels: v.vowel}
+		e.in = []rune(v.in)
+		e.lastIdx = len(e.in) - 1
+		e.idx = v.idx
+
+		fired := e.applyRules(rules)
+		if fired != v.fired || string(e.primBuf) != v.prim || string(e.secondBuf) != v.sec || e.idx != v.nextIdx {
+			t.Errorf("applyRules on '%v' at %v wanted (%v, %v, %v, %v), got (%v, %v, %v, %v)", v.in, v.idx,
+				v.fired, v.prim, v.sec, v.nextIdx, fired, string(e.primBuf), string(e.secondBuf), e.idx)
+		}
+	}
+}
+
+func TestCondKinds(t *testing.T) {
+	// a cond of every kind that matches at index 1 of "SMITH", an unhandled kind never matches
+	conds := map[condKind]cond{
+		condAlways:   {},
+		condAt:       at(0, "MI"),
+		condAtEnd:    atEnd(2, "TH"),
+		condStart:    atStart("SM"),
+		condExact:    exactly("SMITH"),
+		condEnd:      endsWith("ITH"),
+		condContains: contains("IT"),
+		condList:     atList(-1, newWordList("SMI")),
+		condIdx:      idxIs(1),
+		condIdxAbove: idxAbove(0),
+		condLast:     atLast(3),
+		condHas:      has(3),
+		condVowel:    vowelAt(1),
+		condAll:      allOf(has(0), has(1)),
+		condAny:      anyOf(has(9), has(1)),
+		condNot:      not(has(9)),
+	}
+
+	e := &Encoder{}
+	e.in = []rune("SMITH")
+	e.lastIdx = len(e.in) - 1
+	e.idx = 1
+	for k := condKind(0); k < numCondKinds; k++ {
+		c, ok := conds[k]
+		if !ok {
+			t.Errorf("no test cond for kind %v", k)
+			continue
+		}
+		if c.kind != k {
+			t.Errorf("test cond for kind %v has kind %v", k, c.kind)
+		}
+		if !e.test(c) {
+			t.Errorf("cond of kind %v didn't match", k)
+		}
+	}
+}
+
+func TestApplyRules_Do(t *testing.T) {
+	calls := 0
+	rules := []rule{
+		{do: func(e *Encoder) bool { calls++; return false }},
+		{do: func(e *Encoder) bool { calls++; e.metaphAdd('K'); return true }, then: []rule{{add: same("S")}}},
+		{add: same("X")},
+	}
+
+	e := &Encoder{}
+	e.in = []rune("KS")
+	e.lastIdx = len(e.in) - 1
+	if !e.applyRules(rules) || calls != 2 || string(e.primBuf) != "KS" {
+		t.Errorf("wanted the second rule and its sub rule to fire, got %v after %v calls", string(e.primBuf), calls)
+	}
+}
+
+func TestApplyRules_Weight(t *testing.T) {
+	rules := []rule{
+		{when: at(0, "K"), add: altWeight("K", "X", altUnlikely), next: skip(1), then: []rule{{add: alt("S", "Z")}}},
+	}
+
+	e := &Encoder{trackSegments: true}
+	e.in = []rune("KS")
+	e.lastIdx = len(e.in) - 1
+	e.applyRules(rules)
+	if len(e.segments) != 2 || e.segments[0].weight != altUnlikely || e.segments[1].weight != altEven {
+		t.Errorf("wanted an unlikely then an even alternate, got %+v", e.segments)
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	tables := map[string][]rule{
+		"B": rulesB, "C": rulesC, "D": rulesD, "F": rulesF, "G": rulesG, "H": rulesH, "J": rulesJ,
+		"K": rulesK, "L": rulesL, "M": rulesM, "N": rulesN, "P": rulesP, "Q": rulesQ, "R": rulesR,
+		"S": rulesS, "T": rulesT, "V": rulesV, "W": rulesW, "X": rulesX, "Z": rulesZ,
+	}
+	for l, rules := range tables {
+		if err := checkRules(rules); err != nil {
+			t.Errorf("rules for %v: %v", l, err)
+		}
+	}
+
+	for _, rules := range [][]rule{
+		{{name: "both", add: same("S"), exact: same("Z"), vowels: same("SA")}},
+		{{name: "sub", then: []rule{{exact: same("Z"), vowels: same("SA")}}}},
+		{{name: "doWhen", when: at(0, "S"), do: (*Encoder).encodeS}},
+	} {
+		if err := checkRules(rules); err == nil {
+			t.Errorf("wanted an error for %v", rules[0].name)
+		}
+	}
+}
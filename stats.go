@@ -0,0 +1,116 @@
+package metaphone3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Stats counts what Encoders did, e.g. how many inputs had blank keys or were cut at
+// the max length.  Set it as the Stats of an Encoder, or of the options of a Pool to
+// count for all its Encoders.  It's safe to share across goroutines, but Encoders
+// with Stats take about a fifth longer per input, so leave it unset when not needed.
+type Stats struct {
+	mu sync.Mutex
+	s  StatsSnapshot
+}
+
+// StatsSnapshot is a copy of the counts of a Stats.
+type StatsSnapshot struct {
+	// Inputs is the number of inputs encoded, blank inputs included
+	Inputs uint64
+	// Empty is the number of inputs with a blank primary
+	Empty uint64
+	// Truncated is the number of inputs whose keys were cut at the max length, keys
+	// where only silent letters were left over aren't counted
+	Truncated uint64
+	// Alternates is the number of inputs with a secondary
+	Alternates uint64
+	// DroppedRunes is the number of runes that no rule encoded or skipped, e.g. digits
+	// and punctuation left in the input by InputSkip
+	DroppedRunes uint64
+	// Rules is the number of times each rule fired, by the name of the rule
+	Rules map[string]uint64
+}
+
+// NewStats returns a Stats with every count at 0.
+func NewStats() *Stats {
+	return &Stats{s: StatsSnapshot{Rules: make(map[string]uint64)}}
+}
+
+// Snapshot returns a copy of the current counts.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.s
+	out.Rules = make(map[string]uint64, len(s.s.Rules))
+	for r, n := range s.s.Rules {
+		out.Rules[r] = n
+	}
+	return out
+}
+
+// record adds the counts for one input
+func (s *Stats) record(e *Encoder, primary, secondary string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Inputs++
+	if primary == "" {
+		s.s.Empty++
+	}
+	if e.truncated {
+		s.s.Truncated++
+	}
+	if secondary != "" {
+		s.s.Alternates++
+	}
+	s.s.DroppedRunes += uint64(e.dropped)
+	if s.s.Rules == nil {
+		s.s.Rules = make(map[string]uint64)
+	}
+	for _, r := range e.firedRules {
+		s.s.Rules[r]++
+	}
+}
+
+// WritePrometheus writes the counts in the Prometheus text format, with metric names
+// starting with metaphone3_.
+func (s *Stats) WritePrometheus(w io.Writer) error {
+	snap := s.Snapshot()
+	rules := make([]string, 0, len(snap.Rules))
+	for r := range snap.Rules {
+		rules = append(rules, r)
+	}
+	sort.Strings(rules)
+
+	bw := bufio.NewWriter(w)
+	counter := func(name, help string, n uint64) {
+		fmt.Fprintf(bw, "# HELP metaphone3_%v %v\n", name, help)
+		fmt.Fprintf(bw, "# TYPE metaphone3_%v counter\n", name)
+		fmt.Fprintf(bw, "metaphone3_%v %d\n", name, n)
+	}
+	counter("inputs_total", "Inputs encoded.", snap.Inputs)
+	counter("empty_total", "Inputs with a blank primary key.", snap.Empty)
+	counter("truncated_total", "Inputs with keys cut at the max length.", snap.Truncated)
+	counter("alternates_total", "Inputs with a secondary key.", snap.Alternates)
+	counter("dropped_runes_total", "Runes that no rule encoded or skipped.", snap.DroppedRunes)
+
+	fmt.Fprintln(bw, "# HELP metaphone3_rule_fired_total Times each encoding rule fired.")
+	fmt.Fprintln(bw, "# TYPE metaphone3_rule_fired_total counter")
+	for _, r := range rules {
+		fmt.Fprintf(bw, "metaphone3_rule_fired_total{rule=%q} %d\n", r, snap.Rules[r])
+	}
+	return bw.Flush()
+}
+
+// fired records that the named rule fired for the Stats and returns true, so a rule
+// can end with return e.fired(name).  applyRules records the named rules of the
+// tables, only helpers called from inside a rule need it.
+func (e *Encoder) fired(rule string) bool {
+	if e.Stats != nil {
+		e.firedRules = append(e.firedRules, rule)
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package metaphone3
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	s := NewStats()
+	e := &Encoder{Stats: s}
+	for _, w := range []string{"Smith", "Schmidt", "Alexandropavlovskov", "", "Smith3", "Xavier"} {
+		e.Encode(w)
+	}
+
+	snap := s.Snapshot()
+	if snap.Inputs != 6 || snap.Empty != 1 || snap.Truncated != 1 || snap.Alternates != 2 || snap.DroppedRunes != 1 {
+		t.Errorf("unexpected counts %+v", snap)
+	}
+	for rule, n := range map[string]uint64{
+		// hand-written rules
+		"encodeTh":  2,
+		"encodeSch": 1,
+		// fall through to the general handling of a letter
+		"encodeM": 3,
+		// rule tables
+		"encodeInitialX": 1,
+		"encodeDtDd":     1,
+	} {
+		if snap.Rules[rule] != n {
+			t.Errorf("wanted %v to fire %v times, got %v", rule, n, snap.Rules[rule])
+		}
+	}
+
+	// the snapshot is a copy
+	snap.Rules["encodeTh"] = 100
+	if s.Snapshot().Rules["encodeTh"] != 2 {
+		t.Error("changing a snapshot changed the stats")
+	}
+}
+
+func TestStats_Options(t *testing.T) {
+	s := NewStats()
+	e := &Encoder{Stats: s, MaxLength: 4}
+	e.Encode("Smith")
+	e.Encode("Alexander")
+
+	e = &Encoder{Stats: s, FullLength: true}
+	e.Encode("Alexandropavlovskov")
+
+	e = &Encoder{Stats: s, InputPolicy: InputStrip}
+	e.Encode("Smith3")
+
+	if snap := s.Snapshot(); snap.Inputs != 4 || snap.Truncated != 1 || snap.DroppedRunes != 0 {
+		t.Errorf("unexpected counts %+v", snap)
+	}
+}
+
+func TestStats_Truncated(t *testing.T) {
+	s := NewStats()
+	e := &Encoder{Stats: s, MaxLength: 3}
+	// the letters after the cut are silent, so the keys are whole
+	for _, w := range []string{"Smithe", "Smythe", "Abe", "Schmidte"} {
+		full := (&Encoder{FullLength: true}).EncodeKey(w)
+		if k := e.EncodeKey(w); k.Primary != full.Primary || k.Secondary != full.Secondary {
+			t.Fatalf("%v: wanted the full key %v, got %v", w, full, k)
+		}
+	}
+	if snap := s.Snapshot(); snap.Inputs != 4 || snap.Truncated != 0 {
+		t.Errorf("wanted no truncated keys, got %+v", snap)
+	}
+
+	e.Encode("Smithson")
+	if snap := s.Snapshot(); snap.Truncated != 1 {
+		t.Errorf("wanted a truncated key, got %+v", snap)
+	}
+}
+
+func TestStats_SameKeys(t *testing.T) {
+	words := loadWords(t, "testdata/surnames-us.txt")
+	if testing.Short() {
+		words = words[:5000]
+	}
+	for _, n := range []int{1, 3, 4, 8} {
+		for _, vowels := range []bool{false, true} {
+			plain := &Encoder{MaxLength: n, EncodeVowels: vowels}
+			counted := &Encoder{MaxLength: n, EncodeVowels: vowels, Stats: NewStats()}
+			for _, w := range words {
+				p1, s1 := plain.Encode(w)
+				p2, s2 := counted.Encode(w)
+				if p1 != p2 || s1 != s2 {
+					t.Fatalf("%v max length %v vowels %v: wanted %v/%v with Stats, got %v/%v", w, n, vowels, p1, s1, p2, s2)
+				}
+			}
+		}
+	}
+}
+
+func TestStats_Pool(t *testing.T) {
+	s := NewStats()
+	p := NewPool(&Encoder{Stats: s})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p.Encode("Smith")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if snap := s.Snapshot(); snap.Inputs != 800 || snap.Alternates != 800 || snap.Rules["encodeTh"] != 800 {
+		t.Errorf("unexpected counts %+v", snap)
+	}
+}
+
+func TestStats_WritePrometheus(t *testing.T) {
+	s := NewStats()
+	e := &Encoder{Stats: s}
+	e.Encode("Smith")
+	e.Encode("")
+
+	var b strings.Builder
+	if err := s.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP metaphone3_inputs_total Inputs encoded.
+# TYPE metaphone3_inputs_total counter
+metaphone3_inputs_total 2
+# HELP metaphone3_empty_total Inputs with a blank primary key.
+# TYPE metaphone3_empty_total counter
+metaphone3_empty_total 1
+# HELP metaphone3_truncated_total Inputs with keys cut at the max length.
+# TYPE metaphone3_truncated_total counter
+metaphone3_truncated_total 0
+# HELP metaphone3_alternates_total Inputs with a secondary key.
+# TYPE metaphone3_alternates_total counter
+metaphone3_alternates_total 1
+# HELP metaphone3_dropped_runes_total Runes that no rule encoded or skipped.
+# TYPE metaphone3_dropped_runes_total counter
+metaphone3_dropped_runes_total 0
+# HELP metaphone3_rule_fired_total Times each encoding rule fired.
+# TYPE metaphone3_rule_fired_total counter
+metaphone3_rule_fired_total{rule="encodeAnglicisations"} 1
+metaphone3_rule_fired_total{rule="encodeM"} 1
+metaphone3_rule_fired_total{rule="encodeTh"} 1
+metaphone3_rule_fired_total{rule="encodeVowels"} 1
+`
+	if b.String() != want {
+		t.Errorf("wanted\n%v\ngot\n%v", want, b.String())
+	}
+}
+
+func BenchmarkEncode_Stats(b *testing.B) {
+	benchmarkEncode(b, &Encoder{Stats: NewStats()})
+}
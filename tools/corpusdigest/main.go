@@ -0,0 +1,190 @@
+// Command corpusdigest verifies that the encoder output on the test corpora hasn't changed.
+//
+// Every word in each corpus file is encoded with all four combinations of EncodeVowels and
+// EncodeExact and the output is hashed.  The hashes are compared with the ones recorded in
+// testdata/corpus.sha256, so refactors of the rules can be checked to be byte-identical.
+//
+// Usage, from the root of the repo:
+//
+//	go run ./tools/corpusdigest            # check the corpora against the recorded digests
+//	go run ./tools/corpusdigest -write     # record new digests
+//	go run ./tools/corpusdigest -dump dir  # also write the full output of each corpus to dir
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dlclark/metaphone3"
+)
+
+var (
+	digestFile = flag.String("digests", "testdata/corpus.sha256", "file with the recorded digests")
+	write      = flag.Bool("write", false, "record the digests instead of checking them")
+	dump       = flag.String("dump", "", "directory to write the full output of each corpus to")
+)
+
+// the corpora to check when none are given
+var defaultCorpora = []string{
+	"testdata/count_1w.txt",
+	"testdata/firstnames-us.txt",
+	"testdata/surnames-us.txt",
+}
+
+func main() {
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = defaultCorpora
+	}
+
+	digests := make(map[string]string)
+	for _, file := range files {
+		d, err := digestCorpus(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		digests[filepath.ToSlash(file)] = d
+	}
+
+	if *write {
+		if err := writeDigests(*digestFile, digests); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		return
+	}
+
+	want, err := readDigests(*digestFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, file := range files {
+		file = filepath.ToSlash(file)
+		if w, ok := want[file]; !ok {
+			fmt.Printf("%v: no recorded digest\n", file)
+			failed = true
+		} else if w != digests[file] {
+			fmt.Printf("%v: output changed\n", file)
+			failed = true
+		} else {
+			fmt.Printf("%v: ok\n", file)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// digestCorpus encodes every line of the file and returns the hash of the output
+func digestCorpus(file string) (string, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	h := sha256.New()
+	out := io.Writer(h)
+
+	if *dump != "" {
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) + "-metaphone3.out"
+		f, err := os.Create(filepath.Join(*dump, name))
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		out = io.MultiWriter(h, f)
+	}
+
+	// same column order as the .test files
+	encs := []*metaphone3.Encoder{
+		{},
+		{EncodeVowels: true, EncodeExact: true},
+		{EncodeExact: true},
+		{EncodeVowels: true},
+	}
+
+	w := csv.NewWriter(out)
+	scanner := bufio.NewScanner(in)
+	line := make([]string, 0, 1+2*len(encs))
+	for scanner.Scan() {
+		word := scanner.Text()
+		line = append(line[:0], word)
+		for _, e := range encs {
+			prim, sec := e.Encode(word)
+			line = append(line, prim, sec)
+		}
+		if err := w.Write(line); err != nil {
+			return "", err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func readDigests(file string) (map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	digests := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		digests[fields[1]] = fields[0]
+	}
+	return digests, scanner.Err()
+}
+
+// writeDigests writes the digests in sha256sum format, merged with any already recorded
+func writeDigests(file string, digests map[string]string) error {
+	all, err := readDigests(file)
+	if os.IsNotExist(err) {
+		all = make(map[string]string)
+	} else if err != nil {
+		return err
+	}
+	for k, v := range digests {
+		all[k] = v
+	}
+
+	names := make([]string, 0, len(all))
+	for k := range all {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, n := range names {
+		fmt.Fprintf(&sb, "%v  %v\n", all[n], n)
+	}
+	return os.WriteFile(file, []byte(sb.String()), 0644)
+}
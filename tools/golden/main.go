@@ -0,0 +1,193 @@
+// Command golden regenerates the testdata/*-metaphone3.test golden files and summarizes
+// how the current encoder output differs from them.
+//
+// Each line of a golden file is a word followed by the primary and secondary keys for the
+// four combinations of EncodeVowels and EncodeExact.  The summary lists, for each option
+// combination, the words whose keys changed with the old and new keys, so intentional
+// rule changes can be reviewed before the files are rewritten.
+//
+// Usage, from the root of the repo:
+//
+//	go run ./tools/golden            # summarize the changes, exits 1 if there are any
+//	go run ./tools/golden -write     # summarize the changes and rewrite the golden files
+//	go run ./tools/golden -max 0     # list every changed word instead of the first 20
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dlclark/metaphone3"
+)
+
+var (
+	write = flag.Bool("write", false, "rewrite the golden files with the current output")
+	max   = flag.Int("max", 20, "max changed words to list per option combination, 0 for all")
+)
+
+// combo is an option combination in the column order of the golden files
+type combo struct {
+	name string
+	enc  *metaphone3.Encoder
+}
+
+func combos() []combo {
+	return []combo{
+		{"default", &metaphone3.Encoder{}},
+		{"vowels+exact", &metaphone3.Encoder{EncodeVowels: true, EncodeExact: true}},
+		{"exact", &metaphone3.Encoder{EncodeExact: true}},
+		{"vowels", &metaphone3.Encoder{EncodeVowels: true}},
+	}
+}
+
+// change is a word whose keys changed for one option combination
+type change struct {
+	word            string
+	oldPrim, oldSec string
+	newPrim, newSec string
+}
+
+// fileDiff is the result of regenerating one golden file
+type fileDiff struct {
+	words   int
+	changes [][]change // by combo
+	lines   [][]string // regenerated lines
+}
+
+func (d *fileDiff) changed() int {
+	n := 0
+	for _, cs := range d.changes {
+		n += len(cs)
+	}
+	return n
+}
+
+func main() {
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		var err error
+		files, err = filepath.Glob("testdata/*-metaphone3.test")
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	total := 0
+	for _, file := range files {
+		d, err := regenFile(file)
+		if err != nil {
+			fatal(err)
+		}
+		total += d.changed()
+		writeSummary(os.Stdout, filepath.ToSlash(file), d, *max)
+
+		if *write && d.changed() > 0 {
+			if err := writeGolden(file, d.lines); err != nil {
+				fatal(err)
+			}
+		}
+	}
+
+	if total > 0 && !*write {
+		os.Exit(1)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+func regenFile(file string) (*fileDiff, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d, err := regen(f, combos())
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return d, nil
+}
+
+// regen re-encodes every word of a golden file and collects the changes
+func regen(r io.Reader, cs []combo) (*fileDiff, error) {
+	d := &fileDiff{changes: make([][]change, len(cs))}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 1 + 2*len(cs)
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			return d, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		d.words++
+		word := line[0]
+		out := make([]string, 1, len(line))
+		out[0] = word
+		for i, c := range cs {
+			prim, sec := c.enc.Encode(word)
+			oldPrim, oldSec := line[1+2*i], line[2+2*i]
+			if prim != oldPrim || sec != oldSec {
+				d.changes[i] = append(d.changes[i], change{word, oldPrim, oldSec, prim, sec})
+			}
+			out = append(out, prim, sec)
+		}
+		d.lines = append(d.lines, out)
+	}
+}
+
+func writeSummary(w io.Writer, file string, d *fileDiff, max int) {
+	if d.changed() == 0 {
+		fmt.Fprintf(w, "%v: no changes in %v words\n", file, d.words)
+		return
+	}
+
+	fmt.Fprintf(w, "%v: %v changes in %v words\n", file, d.changed(), d.words)
+	for i, c := range combos() {
+		cs := d.changes[i]
+		if len(cs) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  %v: %v words changed\n", c.name, len(cs))
+		for j, ch := range cs {
+			if max > 0 && j == max {
+				fmt.Fprintf(w, "    ... %v more\n", len(cs)-max)
+				break
+			}
+			fmt.Fprintf(w, "    %v: %v → %v\n", ch.word, keys(ch.oldPrim, ch.oldSec), keys(ch.newPrim, ch.newSec))
+		}
+	}
+}
+
+// keys formats a key pair as primary/secondary, or just the primary if there's no secondary
+func keys(prim, sec string) string {
+	if prim == "" {
+		prim = `""`
+	}
+	if sec == "" {
+		return prim
+	}
+	return prim + "/" + sec
+}
+
+func writeGolden(file string, lines [][]string) error {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if err := w.WriteAll(lines); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(sb.String()), 0644)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegen(t *testing.T) {
+	in := "Smith,SM0,XMT,SMAT,XMAT,SMT,XMT,SMA0,XMAT\n" +
+		"Schmidt,SMT,,XMAT,,XMT,,XMAT,\n"
+	d, err := regen(strings.NewReader(in), combos())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, got := 2, d.words; want != got {
+		t.Fatalf("wanted %v words, got %v", want, got)
+	}
+	if want, got := 1, len(d.changes[0]); want != got {
+		t.Fatalf("wanted %v default changes, got %v", want, got)
+	}
+	if want, got := (change{"Schmidt", "SMT", "", "XMT", ""}), d.changes[0][0]; want != got {
+		t.Fatalf("wanted change %v, got %v", want, got)
+	}
+
+	var sb strings.Builder
+	writeSummary(&sb, "test", d, 0)
+	if want, got := "    Schmidt: SMT → XMT\n", sb.String(); !strings.Contains(got, want) {
+		t.Fatalf("wanted summary to contain %q, got %q", want, got)
+	}
+
+	if want, got := "Schmidt,XMT,,XMAT,,XMT,,XMAT,", strings.Join(d.lines[1], ","); want != got {
+		t.Fatalf("wanted line %q, got %q", want, got)
+	}
+}
+
+func TestRegen_BadLine(t *testing.T) {
+	if _, err := regen(strings.NewReader("Smith,SM0\n"), combos()); err == nil {
+		t.Fatal("wanted an error for a short line")
+	}
+}
@@ -0,0 +1,244 @@
+// Command javadiff compares the encoder with the output of the Java v2.1.3 implementation
+// in orig/Metaphone3.java and classifies every difference as a documented fix or a regression.
+//
+// The Java output is produced offline with the main method of orig/Metaphone3.java, which
+// writes each word followed by the primary and secondary keys for the four combinations of
+// EncodeVowels and EncodeExact, the same layout as the testdata golden files:
+//
+//	javac Metaphone3.java && java Metaphone3 words.txt > words-java.csv
+//
+// Each difference is checked against the deviations table (testdata/java-deviations.csv by
+// default).  Differences that aren't in the table are reported as regressions.
+//
+// Usage, from the root of the repo:
+//
+//	go run ./tools/javadiff words-java.csv      # report regressions, exits 1 if there are any
+//	go run ./tools/javadiff -v words-java.csv   # also list the documented fixes
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dlclark/metaphone3"
+)
+
+var (
+	deviationFile = flag.String("deviations", "testdata/java-deviations.csv", "table of intentional differences")
+	verbose       = flag.Bool("v", false, "list the documented fixes as well as the regressions")
+)
+
+// the option combinations in the column order of the Java output
+var comboNames = []string{"default", "vowels+exact", "exact", "vowels"}
+
+func encoders() []*metaphone3.Encoder {
+	return []*metaphone3.Encoder{
+		{},
+		{EncodeVowels: true, EncodeExact: true},
+		{EncodeExact: true},
+		{EncodeVowels: true},
+	}
+}
+
+// deviation is an entry of the deviations table
+type deviation struct {
+	name, pattern, desc string
+	// combos the deviation applies to, nil for all
+	combos map[string]bool
+}
+
+func (d *deviation) matches(word, combo string) bool {
+	if d.combos != nil && !d.combos[combo] {
+		return false
+	}
+	ok, _ := path.Match(d.pattern, strings.ToUpper(word))
+	return ok
+}
+
+// readDeviations reads the CSV table name,pattern,options,description with # comments
+func readDeviations(r io.Reader) ([]deviation, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+
+	var out []deviation
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		d := deviation{name: line[0], pattern: strings.ToUpper(line[1]), desc: line[3]}
+		if d.name == "" || d.pattern == "" {
+			return nil, fmt.Errorf("deviation %q has no name or pattern", line)
+		}
+		if _, err := path.Match(d.pattern, ""); err != nil {
+			return nil, fmt.Errorf("deviation %v: bad pattern %q", d.name, d.pattern)
+		}
+		if line[2] != "" {
+			d.combos = make(map[string]bool)
+			for _, c := range strings.Split(line[2], "|") {
+				if !validCombo(c) {
+					return nil, fmt.Errorf("deviation %v: unknown options %q", d.name, c)
+				}
+				d.combos[c] = true
+			}
+		}
+		out = append(out, d)
+	}
+}
+
+func validCombo(c string) bool {
+	for _, n := range comboNames {
+		if n == c {
+			return true
+		}
+	}
+	return false
+}
+
+// diff is a word whose Go keys differ from the Java keys for one option combination
+type diff struct {
+	word, combo       string
+	javaPrim, javaSec string
+	goPrim, goSec     string
+	// fix is the deviation that documents the difference, nil for a regression
+	fix *deviation
+}
+
+// report is the result of comparing a Java output file
+type report struct {
+	words int
+	diffs []diff
+	// number of differences for each deviation name
+	fixes map[string]int
+}
+
+func (r *report) regressions() int {
+	n := 0
+	for _, d := range r.diffs {
+		if d.fix == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// compare encodes every word of the Java output and classifies the differences
+func compare(r io.Reader, devs []deviation) (*report, error) {
+	encs := encoders()
+	rep := &report{fixes: make(map[string]int)}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 1 + 2*len(encs)
+	for {
+		line, err := reader.Read()
+		if err == io.EOF {
+			return rep, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		rep.words++
+		word := line[0]
+		for i, e := range encs {
+			prim, sec := e.Encode(word)
+			javaPrim, javaSec := line[1+2*i], line[2+2*i]
+			if prim == javaPrim && sec == javaSec {
+				continue
+			}
+
+			d := diff{word, comboNames[i], javaPrim, javaSec, prim, sec, nil}
+			for j := range devs {
+				if devs[j].matches(word, d.combo) {
+					d.fix = &devs[j]
+					rep.fixes[d.fix.name]++
+					break
+				}
+			}
+			rep.diffs = append(rep.diffs, d)
+		}
+	}
+}
+
+func writeReport(w io.Writer, file string, rep *report, verbose bool) {
+	fmt.Fprintf(w, "%v: %v words, %v differences, %v regressions\n", file, rep.words, len(rep.diffs), rep.regressions())
+
+	names := make([]string, 0, len(rep.fixes))
+	for n := range rep.fixes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(w, "  fix %v: %v differences\n", n, rep.fixes[n])
+	}
+
+	for _, d := range rep.diffs {
+		if d.fix == nil {
+			fmt.Fprintf(w, "  REGRESSION %v (%v): java %v, go %v\n", d.word, d.combo, keys(d.javaPrim, d.javaSec), keys(d.goPrim, d.goSec))
+		} else if verbose {
+			fmt.Fprintf(w, "  fix %v %v (%v): java %v, go %v\n", d.fix.name, d.word, d.combo, keys(d.javaPrim, d.javaSec), keys(d.goPrim, d.goSec))
+		}
+	}
+}
+
+// keys formats a key pair as primary/secondary, or just the primary if there's no secondary
+func keys(prim, sec string) string {
+	if prim == "" {
+		prim = `""`
+	}
+	if sec == "" {
+		return prim
+	}
+	return prim + "/" + sec
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fatal(errors.New("usage: javadiff [-v] [-deviations file] java-output.csv ..."))
+	}
+
+	f, err := os.Open(*deviationFile)
+	if err != nil {
+		fatal(err)
+	}
+	devs, err := readDeviations(f)
+	f.Close()
+	if err != nil {
+		fatal(fmt.Errorf("%v: %v", *deviationFile, err))
+	}
+
+	regressions := 0
+	for _, file := range flag.Args() {
+		f, err := os.Open(file)
+		if err != nil {
+			fatal(err)
+		}
+		rep, err := compare(f, devs)
+		f.Close()
+		if err != nil {
+			fatal(fmt.Errorf("%v: %v", file, err))
+		}
+		writeReport(os.Stdout, file, rep, *verbose)
+		regressions += rep.regressions()
+	}
+
+	if regressions > 0 {
+		os.Exit(1)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadTestDeviations(t *testing.T) []deviation {
+	t.Helper()
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "java-deviations.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	devs, err := readDeviations(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return devs
+}
+
+func TestReadDeviations(t *testing.T) {
+	devs := loadTestDeviations(t)
+	for _, name := range []string{"ROBILL", "JAKOB", "CIAS/CIOS", "HARGER", "SUPERNODE", "JUJUY"} {
+		found := false
+		for _, d := range devs {
+			found = found || d.name == name
+		}
+		if !found {
+			t.Errorf("deviation %v is missing", name)
+		}
+	}
+
+	for _, bad := range []string{"X,,,no pattern\n", "X,A[,,bad pattern\n", "X,A,nope,bad options\n", "X,A\n"} {
+		if _, err := readDeviations(strings.NewReader(bad)); err == nil {
+			t.Errorf("wanted an error for %q", bad)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Smith matches, Jujuy and Macias differ as documented and Schmidt is a regression
+	in := "Smith,SM0,XMT,SMA0,XMAT,SM0,XMT,SMA0,XMAT\n" +
+		"Jujuy,JJ,,JAJ,,JJ,,JAJ,\n" +
+		"Macias,MX,,MAXAS,,MX,,MAXAS,\n" +
+		"Schmidt,SMT,,XMAT,,XMT,,XMAT,\n"
+
+	rep, err := compare(strings.NewReader(in), loadTestDeviations(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, got := 4, rep.words; want != got {
+		t.Fatalf("wanted %v words, got %v", want, got)
+	}
+	if want, got := 1, rep.regressions(); want != got {
+		t.Fatalf("wanted %v regressions, got %v", want, got)
+	}
+	if rep.fixes["JUJUY"] == 0 || rep.fixes["CIAS/CIOS"] == 0 {
+		t.Fatalf("wanted JUJUY and CIAS/CIOS fixes, got %v", rep.fixes)
+	}
+
+	var sb strings.Builder
+	writeReport(&sb, "test", rep, false)
+	if want, got := "REGRESSION Schmidt (default): java SMT, go XMT", sb.String(); !strings.Contains(got, want) {
+		t.Fatalf("wanted report to contain %q, got %q", want, got)
+	}
+}
+
+func TestDeviationOptions(t *testing.T) {
+	devs := loadTestDeviations(t)
+	var length *deviation
+	for i := range devs {
+		if devs[i].name == "LENGTH" {
+			length = &devs[i]
+		}
+	}
+	if length == nil {
+		t.Fatal("LENGTH deviation is missing")
+	}
+	if !length.matches("Villafranca", "vowels") {
+		t.Fatal("wanted LENGTH to match Villafranca with vowels")
+	}
+	if length.matches("Villafranca", "default") {
+		t.Fatal("wanted LENGTH not to match Villafranca without vowels")
+	}
+}
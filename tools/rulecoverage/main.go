@@ -0,0 +1,315 @@
+// Command rulecoverage reports how often each encoding rule fires over word lists, with
+// example words, and lists the rules that never fire.
+//
+// Every word is encoded with all four combinations of EncodeVowels and EncodeExact and the
+// rules that fired are counted with a metaphone3.Stats.  The full list of rules is found by
+// scanning the package source for the names in the rule tables, which the rule engine
+// records, and the e.fired calls of the helpers called from inside a rule.  A letter rule,
+// e.g. encodeC, counts the letters that fell through to the general handling after none of
+// the special cases matched.  Encode methods that are neither in a table nor call e.fired
+// can't be counted, they're listed at the end of the report.
+//
+// Usage, from the root of the repo:
+//
+//	go run ./tools/rulecoverage                # cover the surnames and first names corpora
+//	go run ./tools/rulecoverage -unused        # only list the rules that never fire
+//	go run ./tools/rulecoverage words.txt ...  # cover other word lists
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dlclark/metaphone3"
+)
+
+var (
+	src      = flag.String("src", ".", "directory of the package source")
+	examples = flag.Int("examples", 3, "number of example words for each rule")
+	unused   = flag.Bool("unused", false, "only list the rules that never fire")
+)
+
+// the corpora to cover when none are given
+var defaultCorpora = []string{
+	"testdata/surnames-us.txt",
+	"testdata/firstnames-us.txt",
+}
+
+// the option combinations each word is encoded with
+var combos = []metaphone3.Encoder{
+	{},
+	{EncodeVowels: true, EncodeExact: true},
+	{EncodeExact: true},
+	{EncodeVowels: true},
+}
+
+func main() {
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = defaultCorpora
+	}
+
+	rules, uncounted, err := ruleNames(*src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	c := newCoverage(files, *examples)
+	for i, file := range files {
+		if err := c.addFile(i, file); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	if err := c.write(os.Stdout, rules, uncounted, *unused); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+// drivers are the encode methods that run the rules rather than being rules
+var drivers = map[string]bool{"encode": true, "encodeWord": true, "encodeBranches": true}
+
+// ruleNames returns the names of the rules in the package source in dir, and the encode
+// methods that don't record when they fire, both sorted
+func ruleNames(dir string) (rules, uncounted []string, err error) {
+	files, err := parseSource(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make(map[string]bool)
+	add := func(lit ast.Expr) {
+		if bl, ok := lit.(*ast.BasicLit); ok && bl.Kind == token.STRING {
+			if s, err := strconv.Unquote(bl.Value); err == nil && s != "" {
+				names[s] = true
+			}
+		}
+	}
+	// encode methods by whether they call e.fired
+	methods := make(map[string]bool)
+	for _, f := range files {
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "encode") || drivers[fn.Name.Name] {
+				continue
+			}
+			methods[fn.Name.Name] = methods[fn.Name.Name] || callsFired(fn.Body)
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				// e.fired("encodeX")
+				if isFired(n) && len(n.Args) == 1 {
+					add(n.Args[0])
+				}
+			case *ast.KeyValueExpr:
+				// {name: "encodeX", ...} in a rule table
+				if id, ok := n.Key.(*ast.Ident); ok && id.Name == "name" {
+					add(n.Value)
+				}
+			}
+			return true
+		})
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("no rules found in %v", dir)
+	}
+
+	for n := range names {
+		rules = append(rules, n)
+	}
+	for m, fired := range methods {
+		if !fired && !names[m] {
+			uncounted = append(uncounted, m)
+		}
+	}
+	sort.Strings(rules)
+	sort.Strings(uncounted)
+	return rules, uncounted, nil
+}
+
+// parseSource parses the non-test Go files in dir
+func parseSource(dir string) ([]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, p := range paths {
+		if strings.HasSuffix(p, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, p, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func isFired(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "fired"
+}
+
+// callsFired reports whether there's an e.fired call in body
+func callsFired(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && isFired(call) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// coverage counts the rules fired by the words of each file
+type coverage struct {
+	files    []string
+	examples int
+	// fired counts by rule, one for each file
+	fired map[string][]uint64
+	// example words by rule
+	words map[string][]string
+	// the number of words in each file
+	counts []int
+}
+
+func newCoverage(files []string, examples int) *coverage {
+	return &coverage{
+		files:    files,
+		examples: examples,
+		fired:    make(map[string][]uint64),
+		words:    make(map[string][]string),
+		counts:   make([]int, len(files)),
+	}
+}
+
+func (c *coverage) addFile(i int, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.add(i, f)
+}
+
+// add encodes each line of r with every combination of options and counts the rules
+// that fired for the file i
+func (c *coverage) add(i int, r io.Reader) error {
+	encs := make([]*metaphone3.Encoder, len(combos))
+	for j := range combos {
+		e := combos[j]
+		encs[j] = &e
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := scanner.Text()
+		if word == "" {
+			continue
+		}
+		c.counts[i]++
+
+		// a fresh Stats for each word, to know which words fired which rules
+		stats := metaphone3.NewStats()
+		for _, e := range encs {
+			e.Stats = stats
+			e.Encode(word)
+		}
+		for rule, n := range stats.Snapshot().Rules {
+			if c.fired[rule] == nil {
+				c.fired[rule] = make([]uint64, len(c.files))
+			}
+			c.fired[rule][i] += n
+			if len(c.words[rule]) < c.examples {
+				c.words[rule] = append(c.words[rule], word)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// write writes a table of the rules with the times they fired in each file and example
+// words, followed by the rules that never fired and the methods that aren't counted.
+// Rules that fired but aren't in rules are listed too.
+func (c *coverage) write(w io.Writer, rules, uncounted []string, unusedOnly bool) error {
+	all := append([]string(nil), rules...)
+	known := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		known[r] = true
+	}
+	for r := range c.fired {
+		if !known[r] {
+			all = append(all, r)
+		}
+	}
+	sort.Strings(all)
+
+	var never []string
+	for _, r := range all {
+		if c.fired[r] == nil {
+			never = append(never, r)
+		}
+	}
+
+	if !unusedOnly {
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprint(tw, "rule")
+		for i, f := range c.files {
+			fmt.Fprintf(tw, "\t%v (%d words)", filepath.Base(f), c.counts[i])
+		}
+		fmt.Fprintln(tw, "\texamples")
+		for _, r := range all {
+			mark := ""
+			if c.fired[r] == nil {
+				mark = " *"
+			}
+			fmt.Fprint(tw, r+mark)
+			for i := range c.files {
+				n := uint64(0)
+				if c.fired[r] != nil {
+					n = c.fired[r][i]
+				}
+				fmt.Fprintf(tw, "\t%d", n)
+			}
+			fmt.Fprintf(tw, "\t%v\n", strings.Join(c.words[r], ", "))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%d of %d rules never fired:\n", len(never), len(all))
+	for _, r := range never {
+		fmt.Fprintf(w, "    %v\n", r)
+	}
+	if len(uncounted) > 0 {
+		fmt.Fprintf(w, "%d encode methods aren't counted since they don't call e.fired:\n", len(uncounted))
+		for _, m := range uncounted {
+			fmt.Fprintf(w, "    %v\n", m)
+		}
+	}
+	return nil
+}
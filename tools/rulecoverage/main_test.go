@@ -0,0 +1,129 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+	"testing"
+)
+
+func TestRuleNames(t *testing.T) {
+	rules, uncounted, err := ruleNames("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := make(map[string]bool)
+	for _, r := range rules {
+		have[r] = true
+	}
+	// hand-written rules, a letter fall through, and rule tables
+	for _, want := range []string{"encodeGhToF", "encodeSilentFrenchT", "encodeC", "encodeVowels", "encodeInitialX", "encodeDtDd"} {
+		if !have[want] {
+			t.Errorf("wanted %v in the rules", want)
+		}
+	}
+
+	// helpers called from inside a rule record themselves too
+	for _, want := range []string{"encodeESuffix", "encodeLeCases", "encodeLlAsVowelCases"} {
+		if !have[want] {
+			t.Errorf("wanted %v in the rules", want)
+		}
+	}
+	if len(uncounted) > 0 {
+		t.Errorf("wanted every encode method counted, got %v uncounted", uncounted)
+	}
+}
+
+// TestRulesRecordFiring checks every hand-written rule is named in a rule table, records
+// that it fired or only calls other rules, so the coverage doesn't miss any
+func TestRulesRecordFiring(t *testing.T) {
+	files, err := parseSource("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// methods named in a rule table are recorded by applyRules
+	tabled := make(map[string]bool)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if kv, ok := n.(*ast.KeyValueExpr); ok {
+				if id, ok := kv.Key.(*ast.Ident); ok && id.Name == "name" {
+					if bl, ok := kv.Value.(*ast.BasicLit); ok {
+						tabled[strings.Trim(bl.Value, `"`)] = true
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	for _, f := range files {
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !strings.HasPrefix(fn.Name.Name, "encode") ||
+				len(fn.Type.Params.List) > 0 || fn.Type.Results == nil || tabled[fn.Name.Name] {
+				continue
+			}
+
+			records := false
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if sel, ok := call.Fun.(*ast.SelectorExpr); ok &&
+						(sel.Sel.Name == "fired" || strings.HasPrefix(sel.Sel.Name, "encode")) {
+						records = true
+					}
+				}
+				return !records
+			})
+			if !records {
+				t.Errorf("%v doesn't record that it fired", fn.Name.Name)
+			}
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	c := newCoverage([]string{"a.txt", "b.txt"}, 1)
+	if err := c.add(0, strings.NewReader("Smith\nBarraclough\n\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.add(1, strings.NewReader("Smyth\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.counts[0] != 2 || c.counts[1] != 1 {
+		t.Errorf("wanted 2 and 1 words, got %v", c.counts)
+	}
+	// each word is encoded with the four combinations of options
+	if got := c.fired["encodeTh"]; got[0] != 4 || got[1] != 4 {
+		t.Errorf("wanted encodeTh to fire 4 times in each file, got %v", got)
+	}
+	if got := c.words["encodeTh"]; len(got) != 1 || got[0] != "Smith" {
+		t.Errorf("wanted one example of encodeTh, got %v", got)
+	}
+	if got := c.fired["encodeGhToF"]; got[0] == 0 {
+		t.Errorf("wanted encodeGhToF to fire for Barraclough, got %v", got)
+	}
+
+	var sb strings.Builder
+	if err := c.write(&sb, []string{"encodeTh", "encodeGhToF", "encodeNever"}, []string{"encodeHelper"}, false); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"encodeNever *",
+		"a.txt (2 words)",
+		"1 of ",
+		"rules never fired:\n    encodeNever\n",
+		"1 encode methods aren't counted since they don't call e.fired:\n    encodeHelper\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("wanted the report to contain %q, got:\n%v", want, out)
+		}
+	}
+
+	sb.Reset()
+	c.write(&sb, []string{"encodeTh", "encodeNever"}, nil, true)
+	if strings.Contains(sb.String(), "examples") || !strings.Contains(sb.String(), "encodeNever") {
+		t.Errorf("wanted only the rules that never fired, got:\n%v", sb.String())
+	}
+}